@@ -0,0 +1,322 @@
+package crud
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/icons"
+	"github.com/samber/lo"
+)
+
+// Admin routes many entities, each managed by a Crud, under a single
+// endpoint. It adds a menu, grouped by section, to the pages of the
+// entities, and a dashboard with the counts of the entities.
+type Admin struct {
+	endpoint      string
+	entities      []adminEntity
+	funcCanAccess func(r *http.Request, entityKey string) bool
+	funcLocale    func(r *http.Request) string
+	funcLayout    func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
+	homeURL       string
+	menu          string
+	theme         Theme
+	title         string
+	translator    Translator
+}
+
+// adminEntity is an entity of the admin with its Crud
+type adminEntity struct {
+	AdminEntity
+	crud *Crud
+}
+
+// adminSection is a section of the menu with its entities
+type adminSection struct {
+	name     string
+	entities []adminEntity
+}
+
+// Handler routes the request to the dashboard, at the endpoint,
+// or to the Crud of the entity, at the endpoint followed by its key.
+// The paths below the key are routed by the entities with PathRouting.
+func (admin *Admin) Handler(w http.ResponseWriter, r *http.Request) {
+	key := admin.entityKey(r)
+
+	if key == "" {
+		admin.pageDashboard(w, r)
+		return
+	}
+
+	entity, found := admin.entity(key)
+
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !admin.canAccess(r, key) {
+		http.Error(w, admin.page(r).t("Forbidden"), http.StatusForbidden)
+		return
+	}
+
+	http.StripPrefix(admin.endpoint+"/"+key, entity.crud).ServeHTTP(w, r)
+}
+
+// ServeHTTP implements http.Handler
+func (admin *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	admin.Handler(w, r)
+}
+
+// Crud returns the Crud of the entity with the key, nil if not found
+func (admin *Admin) Crud(entityKey string) *Crud {
+	entity, found := admin.entity(entityKey)
+
+	if !found {
+		return nil
+	}
+
+	return entity.crud
+}
+
+func (admin *Admin) UrlDashboard() string {
+	return admin.endpoint
+}
+
+// entity returns the entity with the key
+func (admin *Admin) entity(key string) (adminEntity, bool) {
+	return lo.Find(admin.entities, func(entity adminEntity) bool {
+		return entity.Key == key
+	})
+}
+
+// entityKey returns the key of the entity of the request, the first
+// segment of the path below the endpoint, an empty string for the
+// dashboard
+func (admin *Admin) entityKey(r *http.Request) string {
+	key, _, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, admin.endpoint), "/"), "/")
+	return key
+}
+
+// canAccess returns true if the user of the request can access the entity
+func (admin *Admin) canAccess(r *http.Request, key string) bool {
+	return admin.funcCanAccess == nil || admin.funcCanAccess(r, key)
+}
+
+// sections returns the sections of the menu, in the order of their
+// first entity, with the entities the user of the request can access
+func (admin *Admin) sections(r *http.Request) []adminSection {
+	sections := []adminSection{}
+
+	for _, entity := range admin.entities {
+		if !admin.canAccess(r, entity.Key) {
+			continue
+		}
+
+		_, index, found := lo.FindIndexOf(sections, func(section adminSection) bool {
+			return section.name == entity.Section
+		})
+
+		if !found {
+			sections = append(sections, adminSection{name: entity.Section})
+			index = len(sections) - 1
+		}
+
+		sections[index].entities = append(sections[index].entities, entity)
+	}
+
+	return sections
+}
+
+// label returns the name of the entity shown in the menu
+func (entity adminEntity) label() string {
+	return lo.Ternary(entity.crud.entityNamePlural == "", entity.Key, entity.crud.entityNamePlural)
+}
+
+// count returns the number of entities shown on the dashboard
+func (entity adminEntity) count() (int, error) {
+	if entity.FuncCount != nil {
+		return entity.FuncCount()
+	}
+
+	rows, _, err := entity.crud.rows(RowsQuery{Filters: []FilterValue{}, Columns: []string{}})
+
+	return len(rows), err
+}
+
+// link generates the link to the entity manager of the entity,
+// translated for the page
+func (entity adminEntity) link(page *Crud, class string, color string) *hb.Tag {
+	return hb.Hyperlink().
+		Class(class).
+		Href(entity.crud.UrlEntityManager()).
+		ChildIf(entity.Icon != "", icons.Icon(entity.Icon, 16, 16, color).Style("margin-top:-4px;margin-right:8px;")).
+		Text(page.t(entity.label()))
+}
+
+// wrap adds the menu to the content of the page
+//
+// Parameters:
+// - r: the HTTP request
+// - current: the Crud of the page, highlighted in the menu
+// - content: the content of the page
+//
+// Returns:
+// - string - the content with the menu
+func (admin *Admin) wrap(r *http.Request, current *Crud, content string) string {
+	// the entities are told apart by their endpoint, as the Crud
+	// handling the request is a copy
+	entity, _ := lo.Find(admin.entities, func(entity adminEntity) bool {
+		return entity.crud.endpoint == current.endpoint
+	})
+
+	page := admin.page(r)
+
+	if admin.menu == ADMIN_MENU_TOPBAR {
+		return admin.topbar(r, page, entity.Key).ToHTML() + content
+	}
+
+	return hb.Div().
+		Class("d-flex").
+		Child(admin.sidebar(r, page, entity.Key)).
+		Child(hb.Main().Class("flex-grow-1 py-3").HTML(content)).
+		ToHTML()
+}
+
+// sidebar generates the menu on the side of the pages, highlighting
+// the entity with the current key, or the dashboard if empty
+func (admin *Admin) sidebar(r *http.Request, page *Crud, current string) hb.TagInterface {
+	item := func(link hb.TagInterface) hb.TagInterface {
+		return hb.LI().Class("nav-item").Child(link)
+	}
+
+	list := hb.UL().
+		Class("nav nav-pills flex-column").
+		Child(item(hb.Hyperlink().
+			Class("nav-link text-white" + lo.Ternary(current == "", " active", "")).
+			Href(admin.UrlDashboard()).
+			Child(icons.Icon("bi-speedometer2", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
+			Text(page.t("Dashboard"))))
+
+	for _, section := range admin.sections(r) {
+		list.ChildIf(section.name != "", hb.LI().
+			Class("small text-uppercase text-white-50 mt-3 mb-1 px-3").
+			Text(page.t(section.name)))
+
+		for _, entity := range section.entities {
+			list.Child(item(entity.link(page, "nav-link text-white"+lo.Ternary(entity.Key == current, " active", ""), "white")))
+		}
+	}
+
+	return hb.Nav().
+		Class("bg-dark p-3 flex-shrink-0").
+		Style("width:240px;min-height:100vh;").
+		Child(hb.Hyperlink().
+			Class("d-block fs-5 text-white text-decoration-none mb-3").
+			Href(admin.UrlDashboard()).
+			Text(page.t(admin.title))).
+		Child(list)
+}
+
+// topbar generates the menu on the top of the pages, with a dropdown
+// per section, highlighting the entity with the current key, or the
+// dashboard if empty
+func (admin *Admin) topbar(r *http.Request, page *Crud, current string) hb.TagInterface {
+	list := hb.UL().
+		Class("navbar-nav").
+		Child(hb.LI().Class("nav-item").Child(hb.Hyperlink().
+			Class("nav-link" + lo.Ternary(current == "", " active", "")).
+			Href(admin.UrlDashboard()).
+			Text(page.t("Dashboard"))))
+
+	for _, section := range admin.sections(r) {
+		if section.name == "" {
+			list.Children(lo.Map(section.entities, func(entity adminEntity, _ int) hb.TagInterface {
+				return hb.LI().Class("nav-item").Child(entity.link(page, "nav-link"+lo.Ternary(entity.Key == current, " active", ""), ""))
+			}))
+			continue
+		}
+
+		isActive := lo.SomeBy(section.entities, func(entity adminEntity) bool { return entity.Key == current })
+
+		list.Child(hb.LI().
+			Class("nav-item dropdown").
+			Child(hb.Hyperlink().
+				Class("nav-link dropdown-toggle"+lo.Ternary(isActive, " active", "")).
+				Href("#").
+				Attr("data-bs-toggle", "dropdown").
+				Text(page.t(section.name))).
+			Child(hb.UL().
+				Class("dropdown-menu").
+				Children(lo.Map(section.entities, func(entity adminEntity, _ int) hb.TagInterface {
+					return hb.LI().Child(entity.link(page, "dropdown-item"+lo.Ternary(entity.Key == current, " active", ""), ""))
+				}))))
+	}
+
+	return hb.Nav().
+		Class("navbar navbar-expand navbar-dark bg-dark px-3").
+		Child(hb.Hyperlink().
+			Class("navbar-brand").
+			Href(admin.UrlDashboard()).
+			Text(page.t(admin.title))).
+		Child(list)
+}
+
+// pageDashboard shows the entities the user can access, by section,
+// with their counts
+func (admin *Admin) pageDashboard(w http.ResponseWriter, r *http.Request) {
+	page := admin.page(r)
+
+	breadcrumbs := page._breadcrumbs([]Breadcrumb{
+		{
+			Name: page.t("Home"),
+			URL:  admin.homeURL,
+		},
+	})
+
+	container := hb.Div().
+		Class("container").
+		Child(hb.Heading1().Text(page.t(admin.title))).
+		Child(hb.Raw(breadcrumbs))
+
+	for _, section := range admin.sections(r) {
+		cards := lo.Map(section.entities, func(entity adminEntity, _ int) hb.TagInterface {
+			count, err := entity.count()
+
+			return hb.Div().
+				Class("col-md-3").
+				Child(hb.Div().
+					Class("card h-100").
+					Child(hb.Div().
+						Class("card-body").
+						Child(entity.link(page, "stretched-link text-decoration-none", "")).
+						Child(hb.Div().
+							Class("fs-2 fw-bold").
+							Text(lo.Ternary(err != nil, "–", strconv.Itoa(count))))))
+		})
+
+		container.
+			ChildIf(section.name != "", hb.Heading5().Class("mt-4").Text(page.t(section.name))).
+			Child(hb.Div().Class("row g-3 mt-1").Children(cards))
+	}
+
+	html := page.layout(w, r, page.t(admin.title), container.ToHTML(), []string{}, "", []string{}, "")
+
+	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(html))
+}
+
+// page returns the Crud rendering the pages of the admin itself, in the
+// locale of the request
+func (admin *Admin) page(r *http.Request) *Crud {
+	return &Crud{
+		admin:      admin,
+		funcLayout: admin.funcLayout,
+		homeURL:    admin.homeURL,
+		locale:     requestLocale(admin.funcLocale, r),
+		theme:      admin.theme,
+		translator: admin.translator,
+	}
+}
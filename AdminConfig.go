@@ -0,0 +1,43 @@
+package crud
+
+import (
+	"net/http"
+)
+
+type AdminConfig struct {
+	// Endpoint is the prefix the entities are routed under, e.g. "/admin"
+	Endpoint string
+
+	// Entities are the entities of the admin, in the order of the menu
+	Entities []AdminEntity
+
+	// FuncCanAccess returns true if the user of the request can access
+	// the entity with the key, optional. The inaccessible entities are
+	// left out of the menu and the dashboard.
+	FuncCanAccess func(r *http.Request, entityKey string) bool
+
+	// FuncLocale returns the locale of the request, defaults to the first
+	// language of the Accept-Language header. Applies to the entities
+	// without one.
+	FuncLocale func(r *http.Request) string
+
+	FuncLayout func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
+
+	// HomeURL is the URL of the Home breadcrumb, defaults to the dashboard
+	HomeURL string
+
+	// Menu is ADMIN_MENU_SIDEBAR or ADMIN_MENU_TOPBAR,
+	// defaults to ADMIN_MENU_SIDEBAR
+	Menu string
+
+	// Theme renders the pages of the admin and of the entities without
+	// a Theme, defaults to NewBootstrapTheme
+	Theme Theme
+
+	// Translator translates the menu, the dashboard and the entities
+	// without a Translator
+	Translator Translator
+
+	// Title is shown in the menu and on the dashboard, defaults to "Admin"
+	Title string
+}
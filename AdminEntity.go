@@ -0,0 +1,22 @@
+package crud
+
+// AdminEntity is an entity of the admin, managed by a Crud routed
+// under the endpoint of the admin
+type AdminEntity struct {
+	// Key is the URL segment of the entity, e.g. "users" for "/admin/users"
+	Key string
+
+	// Section groups the entities in the menu, optional
+	Section string
+
+	// Icon is the Bootstrap icon of the entity, e.g. "bi-people"
+	Icon string
+
+	// Config is the configuration of the Crud of the entity. The Endpoint
+	// and the HomeURL are set by the admin, as is the FuncLayout if not set.
+	Config CrudConfig
+
+	// FuncCount returns the number of entities shown on the dashboard,
+	// optional. Defaults to the number of rows of the entity manager.
+	FuncCount func() (int, error)
+}
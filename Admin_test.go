@@ -0,0 +1,79 @@
+package crud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdmin(t *testing.T) {
+	entity := func(key string, section string, name string) AdminEntity {
+		return AdminEntity{
+			Key:     key,
+			Section: section,
+			Icon:    "bi-people",
+			Config: CrudConfig{
+				EntityNamePlural:   name + "s",
+				EntityNameSingular: name,
+				UpdateFields:       []FormField{},
+				FuncRows: func() ([]Row, error) {
+					return []Row{{ID: "1", Data: []string{}}, {ID: "2", Data: []string{}}}, nil
+				},
+			},
+		}
+	}
+
+	invoices := entity("invoices", "Billing", "Invoice")
+	invoices.FuncCount = func() (int, error) {
+		return 42, nil
+	}
+
+	admin, err := NewAdmin(AdminConfig{
+		Endpoint: "/admin/",
+		Title:    "Back Office",
+		Entities: []AdminEntity{entity("users", "", "User"), invoices, entity("payments", "Billing", "Payment")},
+		FuncCanAccess: func(r *http.Request, entityKey string) bool {
+			return entityKey != "payments"
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	w := httptest.NewRecorder()
+	admin.Handler(w, httptest.NewRequest("GET", "/admin", nil))
+	body := w.Body.String()
+
+	for _, html := range []string{`>Back Office</a>`, `href="/admin/users?path=entity-manager"`, `>Billing</li>`, `>Invoices</a><div class="fs-2 fw-bold">42</div>`, `>Users</a><div class="fs-2 fw-bold">2</div>`} {
+		if !strings.Contains(body, html) {
+			t.Error("Dashboard MUST contain ", html)
+		}
+	}
+
+	if strings.Contains(body, "Payments") {
+		t.Error("Dashboard MUST NOT contain the inaccessible entities")
+	}
+
+	w = httptest.NewRecorder()
+	admin.Handler(w, httptest.NewRequest("GET", "/admin/users?path=entity-manager", nil))
+	body = w.Body.String()
+
+	for _, html := range []string{`User Manager`, `<a class="nav-link text-white active" href="/admin/users?path=entity-manager">`, `<a href="/admin">Home</a>`} {
+		if !strings.Contains(body, html) {
+			t.Error("Entity manager MUST contain ", html)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	admin.Handler(w, httptest.NewRequest("GET", "/admin/payments", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Error("Status MUST be 403, but found: ", w.Code)
+	}
+
+	if admin.Crud("invoices") == nil || admin.Crud("unknown") != nil {
+		t.Error("Crud MUST be found by the key of the entity")
+	}
+}
@@ -0,0 +1,115 @@
+package crud
+
+import (
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// bootstrapTheme is the Bootstrap 5 theme
+type bootstrapTheme struct{}
+
+var _ Theme = bootstrapTheme{}
+
+// NewBootstrapTheme returns the Bootstrap 5 theme, the default theme
+func NewBootstrapTheme() Theme {
+	return bootstrapTheme{}
+}
+
+func (theme bootstrapTheme) Page(page ThemePage) string {
+	faviconImgCms := `data:image/x-icon;base64,AAABAAEAEBAQAAEABAAoAQAAFgAAACgAAAAQAAAAIAAAAAEABAAAAAAAgAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAmzKzAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABEQEAAQERAAEAAQABAAEAAQABAQEBEQABAAEREQEAAAERARARAREAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD//wAA//8AAP//AAD//wAA//8AAP//AAD//wAAi6MAALu7AAC6owAAuC8AAIkjAAD//wAA//8AAP//AAD//wAA`
+	webpage := hb.Webpage()
+	webpage.SetTitle(page.Title)
+	webpage.SetFavicon(faviconImgCms)
+
+	webpage.AddStyleURLs(theme.StyleURLs())
+	webpage.AddScriptURLs(theme.ScriptURLs())
+	webpage.AddStyle(`html,body{height:100%;font-family: Ubuntu, sans-serif;}`)
+	webpage.AddStyle(`body {
+		font-family: "Nunito", sans-serif;
+		font-size: 0.9rem;
+		font-weight: 400;
+		line-height: 1.6;
+		color: #212529;
+		text-align: left;
+		background-color: #f8fafc;
+	}
+	.form-select {
+		display: block;
+		width: 100%;
+		padding: .375rem 2.25rem .375rem .75rem;
+		font-size: 1rem;
+		font-weight: 400;
+		line-height: 1.5;
+		color: #212529;
+		background-color: #fff;
+		background-image: url("data:image/svg+xml,%3csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 16 16'%3e%3cpath fill='none' stroke='%23343a40' stroke-linecap='round' stroke-linejoin='round' stroke-width='2' d='M2 5l6 6 6-6'/%3e%3c/svg%3e");
+		background-repeat: no-repeat;
+		background-position: right .75rem center;
+		background-size: 16px 12px;
+		border: 1px solid #ced4da;
+		border-radius: .25rem;
+		-webkit-appearance: none;
+		-moz-appearance: none;
+		appearance: none;
+	}`)
+	webpage.Child(hb.Raw(page.Content))
+	webpage.AddStyleURLs(page.StyleURLs)
+	webpage.AddStyle(page.Style)
+	webpage.AddScriptURLs(page.ScriptURLs)
+	webpage.AddScript(page.Script)
+
+	return webpage.ToHTML()
+}
+
+func (theme bootstrapTheme) StyleURLs() []string {
+	return []string{
+		"https://cdn.jsdelivr.net/npm/bootstrap@5.0.0-beta3/dist/css/bootstrap.min.css",
+	}
+}
+
+func (theme bootstrapTheme) ScriptURLs() []string {
+	return []string{
+		"https://cdn.jsdelivr.net/npm/bootstrap@5.0.0-beta3/dist/js/bootstrap.bundle.min.js",
+	}
+}
+
+func (theme bootstrapTheme) Style() string {
+	return ""
+}
+
+func (theme bootstrapTheme) Script() string {
+	return ""
+}
+
+func (theme bootstrapTheme) InputClass(fieldType string) string {
+	if fieldType == FORM_FIELD_TYPE_SELECT || fieldType == FORM_FIELD_TYPE_MULTISELECT {
+		return "form-select"
+	}
+
+	return "form-control"
+}
+
+func (theme bootstrapTheme) FormGroup(label hb.TagInterface, input hb.TagInterface, help string) *hb.Tag {
+	return hb.Div().
+		Class("form-group mt-3").
+		ChildIf(label != nil, label).
+		Child(input).
+		ChildIf(help != "", hb.Paragraph().Class("text-info").HTML(help))
+}
+
+func (theme bootstrapTheme) ButtonClass(variant string, small bool) string {
+	return "btn" + lo.Ternary(small, " btn-sm", "") + " btn-" + variant
+}
+
+func (theme bootstrapTheme) AlertClass(variant string) string {
+	return "alert alert-" + variant
+}
+
+func (theme bootstrapTheme) Modal(modal ThemeModal) hb.TagInterface {
+	return hb.Div().ID(modal.ID).Class("modal fade").
+		Child(hb.Div().Class("modal-dialog").ClassIf(modal.Large, "modal-lg").
+			Child(hb.Div().Class("modal-content").
+				Child(hb.Div().Class("modal-header").Child(hb.Heading5().Text(modal.Title))).
+				Child(hb.Div().Class("modal-body").Children(modal.Body)).
+				Child(hb.Div().Class("modal-footer").Children(modal.Footer))))
+}
@@ -0,0 +1,195 @@
+package crud
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalogTranslator(t *testing.T) {
+	translator := NewCatalogTranslator("en")
+
+	err := translator.LoadFS(fstest.MapFS{
+		"i18n/de.json": {Data: []byte(`{"Save": "Speichern", "New {name}": "Neuer {name}", "User": "Benutzer"}`)},
+		"i18n/bg.yaml": {Data: []byte("Save: Запази\n")},
+		"i18n/en.yml":  {Data: []byte("Oops...: Sorry\n")},
+		"i18n/README":  {Data: []byte("ignored")},
+	}, "i18n")
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	cases := []struct {
+		locale   string
+		key      string
+		params   map[string]string
+		expected string
+	}{
+		{"de", "Save", nil, "Speichern"},
+		{"de_AT", "Save", nil, "Speichern"},
+		{"bg-BG", "Save", nil, "Запази"},
+		{"de", "New {name}", map[string]string{"name": "Benutzer"}, "Neuer Benutzer"},
+		{"de", "Oops...", nil, "Sorry"},
+		{"fr", "Apply", nil, "Apply"},
+	}
+
+	for _, c := range cases {
+		if actual := translator.Translate(c.locale, c.key, c.params); actual != c.expected {
+			t.Error("Translation of "+c.key+" in "+c.locale+" MUST be "+c.expected+", but found: ", actual)
+		}
+	}
+
+	if err := translator.LoadFS(fstest.MapFS{"i18n/fr.json": {Data: []byte(`[`)}}, "i18n"); err == nil {
+		t.Error("Error MUST NOT be nil for an invalid catalog")
+	}
+}
+
+func TestEntityManagerTranslated(t *testing.T) {
+	translator := NewCatalogTranslator("en")
+	translator.AddMessages("de", map[string]string{
+		"User":                      "Benutzer",
+		"{name} Manager":            "{name}-Verwaltung",
+		"New {name}":                "Neuer {name}",
+		"Name":                      "Name",
+		"Status":                    "Status",
+		"Active":                    "Aktiv",
+		"{label} is required field": "{label} ist ein Pflichtfeld",
+		"Oops...":                   "Hoppla...",
+		"The full name of the user": "Der vollständige Name",
+	})
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:           "/users",
+		EntityNameSingular: "User",
+		EntityNamePlural:   "Users",
+		Translator:         translator,
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name", Help: "The full name of the user", Required: true},
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "status", Label: "Status", Options: []FormFieldOption{{Key: "active", Value: "Active"}}},
+		},
+		UpdateFields: []FormField{},
+		FuncCreate: func(data map[string]string) (string, error) {
+			return "E1", nil
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", crud.UrlEntityManager(), nil)
+	r.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	body := w.Body.String()
+
+	for _, expected := range []string{
+		"Benutzer-Verwaltung",
+		"Neuer Benutzer",
+		"Der vollständige Name",
+		`<option value="active">Aktiv</option>`,
+		`"Oops...":"Hoppla..."`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Error("Manager page MUST contain " + expected)
+		}
+	}
+
+	r = httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(url.Values{"status": {"active"}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept-Language", "de")
+	w = httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), "Name ist ein Pflichtfeld") {
+		t.Error("Error MUST be translated, but found: ", w.Body.String())
+	}
+}
+
+func TestEntityFieldsTranslated(t *testing.T) {
+	translator := NewCatalogTranslator("en")
+	translator.AddMessages("de", map[string]string{
+		"Product":        "Produkt",
+		"{name} Details": "{name}-Details",
+		"Valid JSON":     "Gültiges JSON",
+		"Bold":           "Fett",
+		"Street":         "Straße",
+		"Home":           "Zuhause",
+		"Yes":            "Ja",
+	})
+
+	fields := []FormField{
+		{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title"},
+		{Type: FORM_FIELD_TYPE_JSON, Name: "settings", Label: "Settings"},
+		{Type: FORM_FIELD_TYPE_MARKDOWN, Name: "notes", Label: "Notes"},
+		{Type: FORM_FIELD_TYPE_CHECKBOX, Name: "active", Label: "Active"},
+		{Type: FORM_FIELD_TYPE_REPEATER, Name: "addresses", Label: "Addresses", Fields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "street", Label: "Street"},
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "kind", Label: "Kind", Options: []FormFieldOption{{Key: "home", Value: "Home"}}},
+		}},
+	}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:           "/products",
+		EntityNameSingular: "Product",
+		Translator:         translator,
+		Columns:            []Column{{Name: "Title"}, {Name: "Active", Field: "active"}},
+		UpdateFields:       fields,
+		ReadFields:         fields,
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"title": "Shirt", "active": "0", "addresses": `[{"street":"Main","kind":"home"}]`}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	get := func(url string) string {
+		r := httptest.NewRequest("GET", url, nil)
+		r.Header.Set("Accept-Language", "de")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		return w.Body.String()
+	}
+
+	body := get(crud.UrlEntityUpdate() + "&entity_id=P1")
+
+	for _, expected := range []string{"Gültiges JSON", `title="Fett"`, "Straße", `<option value="home">Zuhause</option>`} {
+		if !strings.Contains(body, expected) {
+			t.Error("Update page MUST contain " + expected)
+		}
+	}
+
+	body = get(crud.UrlEntityReadByID("P1"))
+
+	for _, expected := range []string{"Produkt-Details", "<th>Straße</th>", "Zuhause"} {
+		if !strings.Contains(body, expected) {
+			t.Error("Read page MUST contain " + expected)
+		}
+	}
+
+	form := url.Values{"entity_id": {"P1"}, "field": {"active"}, "active": {"1"}}
+	r := httptest.NewRequest("POST", crud.UrlEntityInlineUpdateAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"display":"Ja"`) {
+		t.Error("Inline value MUST be translated, but found: ", w.Body.String())
+	}
+}
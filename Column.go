@@ -0,0 +1,39 @@
+package crud
+
+// Column defines a column of the entity manager table.
+//
+// The Name is shown in the table heading. Wrapping the name in {!! !!}
+// renders the cells of the column as raw HTML.
+type Column struct {
+	Name string
+
+	// Relation is the Crud of the related entity, when the column holds
+	// the ID of another entity. The cell is shown as the label of the
+	// related entity, linked to its read page.
+	Relation *Crud
+
+	// Field is the name of the update field edited inline in the cells
+	// of the column, optional. Clicking a cell shows the input of the
+	// field, and the value is saved on its own, keeping the other values
+	// of the entity.
+	Field string
+
+	// Hidden hides the column by default. The users can show it from
+	// the Columns dropdown of the entity manager.
+	Hidden bool
+
+	// Format formats the values of the column in the locale and the
+	// timezone of the user, one of the FORMAT_* constants, optional.
+	// The values which cannot be parsed are shown as they are, and the
+	// exports hold the values as they are.
+	Format string
+
+	// Currency is the ISO 4217 code of the currency of the values of
+	// the FORMAT_CURRENCY columns, e.g. "EUR"
+	Currency string
+}
+
+// label returns the name of the column without the raw HTML markers
+func (column Column) label() string {
+	return stripRawMarkers(column.Name)
+}
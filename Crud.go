@@ -3,6 +3,7 @@ package crud
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gouniverse/api"
@@ -12,126 +13,213 @@ import (
 	"github.com/gouniverse/icons"
 	"github.com/gouniverse/utils"
 	"github.com/samber/lo"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 type Crud struct {
-	columnNames         []string
+	admin               *Admin
+	columns             []Column
+	children            []*Crud
 	createFields        []FormField
 	endpoint            string
 	entityNamePlural    string
 	entityNameSingular  string
+	fieldGroups         []FieldGroup
 	fileManagerURL      string
+	filters             []Filter
 	funcCreate          func(data map[string]string) (userID string, err error)
+	funcCreateDefaults  func(r *http.Request) map[string]string
+	funcDuplicate       func(entityID string, data map[string]string) map[string]string
 	funcReadExtras      func(entityID string) []hb.TagInterface
+	funcFetchLabels     func(entityIDs []string) (map[string]string, error)
 	funcFetchReadData   func(entityID string) ([][2]string, error)
+	funcFetchReadValues func(entityID string) (map[string]string, error)
 	funcFetchUpdateData func(entityID string) (map[string]string, error)
+	funcLocale          func(r *http.Request) string
 	funcLayout          func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
 	funcRows            func() (rows []Row, err error)
+	funcRowsQuery       func(query RowsQuery) (rows []Row, facets map[string]map[string]int, err error)
+	funcSearch          func(query string, page int) (options []FormFieldOption, hasMore bool, err error)
+	funcTimezone        func(r *http.Request) string
 	funcTrash           func(entityID string) error
 	funcUpdate          func(entityID string, data map[string]string) error
+	funcUpdateChanges   func(entityID string, changes map[string]string, before map[string]string, after map[string]string) error
+	funcUserID          func(r *http.Request) string
+	funcRowsByParent    func(parentID string) (rows []Row, err error)
 	homeURL             string
+	jsonSchemas         map[string]*jsonschema.Schema
+	locale              string
+	middlewares         []Middleware
+	parentKey           string
+	pathRouting         bool
+	preferencesStore    PreferencesStore
 	readFields          []FormField
+	theme               Theme
+	timezone            string
+	translator          Translator
 	updateFields        []FormField
 }
 
 func (crud Crud) Handler(w http.ResponseWriter, r *http.Request) {
-	path := utils.Req(r, "path", "home")
+	path := utils.Req(r, "path", "")
+
+	// the path query parameter takes precedence, so that the URLs
+	// of the query routing keep working with the path routing
+	if path == "" && crud.pathRouting {
+		route, routed, found := pathRoute(r)
+
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		path, r = route, routed
+	}
 
 	if path == "" {
 		path = "home"
 	}
 
+	// the Crud is a copy for the request, shown in its locale
+	crud.locale = requestLocale(crud.funcLocale, r)
+
 	ctx := context.WithValue(r.Context(), "", r.URL.Path)
+	r = r.WithContext(ctx)
+
+	route, routeFunc := crud.getRoute(path)
+
+	action := Action{
+		Route:    route,
+		EntityID: strings.TrimSpace(utils.Req(r, "entity_id", "")),
+	}
 
-	routeFunc := crud.getRoute(path)
-	routeFunc(w, r.WithContext(ctx))
+	chain(crud.middlewares, action, routeFunc)(w, r)
 }
 
-func (crud *Crud) getRoute(route string) func(w http.ResponseWriter, r *http.Request) {
+// getRoute returns the name of the route and its handler, the entity
+// manager for the home and the unknown routes
+func (crud *Crud) getRoute(route string) (string, func(w http.ResponseWriter, r *http.Request)) {
 	routes := map[string]func(w http.ResponseWriter, r *http.Request){
 		"home": crud.pageEntityManager,
 		// START: Custom Entities
-		pathEntityCreateAjax: crud.pageEntityCreateAjax,
-		pathEntityManager:    crud.pageEntityManager,
-		pathEntityRead:       crud.pageEntityRead,
-		pathEntityUpdate:     crud.pageEntityUpdate,
-		pathEntityUpdateAjax: crud.pageEntityUpdateAjax,
-		pathEntityTrashAjax:  crud.pageEntityTrashAjax,
+		pathEntityColumnsSaveAjax:     crud.pageEntityColumnsSaveAjax,
+		pathEntityCreateAjax:          crud.pageEntityCreateAjax,
+		pathEntityExport:              crud.pageEntityExport,
+		pathEntityManager:             crud.pageEntityManager,
+		pathEntityMarkdownPreviewAjax: crud.pageEntityMarkdownPreviewAjax,
+		pathEntityOptionsAjax:         crud.pageEntityOptionsAjax,
+		pathEntityRead:                crud.pageEntityRead,
+		pathEntityUpdate:              crud.pageEntityUpdate,
+		pathEntityUpdateAjax:          crud.pageEntityUpdateAjax,
+		pathEntityTrashAjax:           crud.pageEntityTrashAjax,
+		pathEntitySearchAjax:          crud.pageEntitySearchAjax,
+		pathEntityFetchAjax:           crud.pageEntityFetchAjax,
+		pathEntityInlineUpdateAjax:    crud.pageEntityInlineUpdateAjax,
+		pathEntityViewDeleteAjax:      crud.pageEntityViewDeleteAjax,
+		pathEntityViewSaveAjax:        crud.pageEntityViewSaveAjax,
 		// END: Custom Entities
 
 	}
 	// log.Println(route)
-	if val, ok := routes[route]; ok {
-		return val
+	if val, ok := routes[route]; ok && route != "home" {
+		return route, val
 	}
 
-	return routes["home"]
+	return pathEntityManager, routes["home"]
 }
 
 func (crud *Crud) pageEntityCreateAjax(w http.ResponseWriter, r *http.Request) {
-	names := crud.listCreateNames()
+	names := crud.listCreateNames(r)
 
 	posts := map[string]string{}
 	for _, name := range names {
-		posts[name] = utils.Req(r, name, "")
+		field, _ := fieldByName(crud.createFields, name)
+		posts[name] = requestValue(r, field)
 	}
 
-	// Check required fields
-	for _, field := range crud.createFields {
-		if !field.Required {
-			continue
-		}
+	// The values kept from the entity being duplicated, as changed by FuncDuplicate
+	if errorMessage := crud.applyDuplicate(r, posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
 
-		if _, exists := posts[field.Name]; !exists {
-			api.Respond(w, r, api.Error(field.Label+" is required field"))
-			return
-		}
+	// Set the computed values, validated like the posted ones
+	computeValues(r, crud.createFields, posts)
 
-		if lo.IsEmpty(posts[field.Name]) {
-			api.Respond(w, r, api.Error(field.Label+" is required field"))
-			return
-		}
+	// Validate the fields, skipping the hidden ones
+	if errorMessage := crud.validateFields(editableFields(r, crud.createFields), posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
+
+	// Convert the dates from the timezone of the user to the storage format
+	if errorMessage := crud.storeDateValues(r, crud.createFields, posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
+
+	// The defaults of the fields which cannot be edited, in storage format
+	posts = lo.Assign(posts, crud.lockedCreateDefaults(r))
+
+	// The ID of the parent entity, when created from the grid of the parent.
+	// It is posted by the client, so FuncCreate must authorize it
+	if crud.parentKey != "" {
+		posts[crud.parentKey] = utils.Req(r, crud.parentKey, "")
 	}
 
 	entityID, err := crud.funcCreate(posts)
 
 	if err != nil {
-		api.Respond(w, r, api.Error("Save failed: "+err.Error()))
+		api.Respond(w, r, api.Error(crud.t("Save failed: {error}", "error", err.Error())))
 		return
 	}
 
-	api.Respond(w, r, api.SuccessWithData("Saved successfully", map[string]interface{}{"entity_id": entityID}))
+	api.Respond(w, r, api.SuccessWithData(crud.t("Saved successfully"), map[string]interface{}{
+		"entity_id":  entityID,
+		"update_url": crud.UrlEntityUpdateByID(entityID),
+	}))
 }
 
 func (crud *Crud) pageEntityManager(w http.ResponseWriter, r *http.Request) {
 	// header := cms.cmsHeader(endpoint)
 	breadcrumbs := crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: crud.t("Home"),
 			URL:  crud.urlHome(),
 		},
 		{
-			Name: crud.entityNameSingular + " Manager",
+			Name: crud.tEntity("{name} Manager"),
 			URL:  crud.UrlEntityManager(),
 		},
 	})
 
 	buttonCreate := hb.Button().
-		Class("btn btn-success float-end").
+		Class(crud.theme.ButtonClass(BUTTON_SUCCESS, false)+" float-end").
 		Attr("v-on:click", "showEntityCreateModal").
 		AddChild(icons.Icon("bi-plus-circle", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("New " + crud.entityNameSingular)
+		HTML(crud.tEntity("New {name}"))
+
+	query, viewName := crud.rowsQuery(r)
+	isColumnsSet := len(query.Columns) > 0
+	if !isColumnsSet {
+		query.Columns = crud.userColumns(r)
+	}
+	rows, facets, errRows := crud.rows(query)
+	columns := crud.visibleColumns(query)
 
 	heading := hb.Heading1().
-		HTML(crud.entityNameSingular + " Manager").
-		Child(buttonCreate)
+		HTML(crud.tEntity("{name} Manager")).
+		Child(buttonCreate).
+		Child(crud.exportButton(query)).
+		Child(crud.viewSwitcher(r, viewName)).
+		Child(crud.columnsDropdown())
 
-	rows, errRows := crud.funcRows()
+	relationLabels := crud.relationLabels(rows)
 
 	tableContent := lo.IfF(errRows != nil, func() hb.TagInterface {
 		alert := hb.Div().
-			Class("alert alert-danger").
-			HTML("There was an error retrieving the data. Please try again later")
+			Class(crud.theme.AlertClass(ALERT_DANGER)).
+			HTML(crud.t("There was an error retrieving the data. Please try again later"))
 
 		return alert
 	}).ElseF(func() hb.TagInterface {
@@ -142,57 +230,55 @@ func (crud *Crud) pageEntityManager(w http.ResponseWriter, r *http.Request) {
 				hb.Thead().
 					Children([]hb.TagInterface{
 						hb.TR().
-							Children(lo.Map(crud.columnNames, func(columnName string, _ int) hb.TagInterface {
-								columnName = strings.ReplaceAll(columnName, "{!!", "")
-								columnName = strings.ReplaceAll(columnName, "!!}", "")
-								return hb.TH().Text(columnName)
-							})).
+							Children(crud.tableHeaders(columns)).
 							Child(hb.TD().
-								HTML("Actions").
+								HTML(crud.t("Actions")).
 								Style("width:120px;")),
 					})).
 			Child(
 				hb.Tbody().
 					Children(lo.Map(rows, func(row Row, _ int) hb.TagInterface {
 						buttonView := hb.Hyperlink().
-							Class("btn btn-sm btn-outline-info").
+							Class(crud.theme.ButtonClass(BUTTON_OUTLINE_INFO, true)).
 							Child(icons.Icon("bi-eye", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Show").
-							Href(crud.UrlEntityRead() + "&entity_id=" + row.ID).
+							Attr("title", crud.t("Show")).
+							Href(crud.UrlEntityReadByID(row.ID)).
 							Style("margin-right:5px")
 
 						buttonEdit := hb.Hyperlink().
-							Class("btn btn-sm btn-outline-warning").
+							Class(crud.theme.ButtonClass(BUTTON_OUTLINE_WARNING, true)).
 							Child(icons.Icon("bi-pencil-square", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Edit").
+							Attr("title", crud.t("Edit")).
 							Attr("type", "button").
-							Href(crud.UrlEntityUpdate() + "&entity_id=" + row.ID).
+							Href(crud.UrlEntityUpdateByID(row.ID)).
+							Style("margin-right:5px")
+
+						buttonDuplicate := hb.Hyperlink().
+							Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, true)).
+							Child(icons.Icon("bi-files", 18, 18, "#333").
+								Style("margin-top:-4px;")).
+							Attr("title", crud.t("Duplicate")).
+							Href(crud.UrlEntityDuplicate(row.ID)).
 							Style("margin-right:5px")
 
 						buttonTrash := hb.Button().
-							Class("btn btn-sm btn-outline-danger").
+							Class(crud.theme.ButtonClass(BUTTON_OUTLINE_DANGER, true)).
 							Child(icons.Icon("bi-trash", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Trash").
+							Attr("title", crud.t("Trash")).
 							Attr("type", "button").
 							Attr("v-on:click", "showEntityTrashModal('"+row.ID+"')")
 
 						tr := hb.TR().
-							Children(lo.Map(row.Data, func(cell string, index int) hb.TagInterface {
-								name := crud.columnNames[index]
-								isRaw := strings.HasPrefix(name, "{!!") && strings.HasSuffix(name, "!!}")
-								cell = strings.ReplaceAll(cell, "{!!", "")
-								cell = strings.ReplaceAll(cell, "!!}", "")
-								cell = strings.TrimSpace(cell)
-								return hb.TD().TextIf(!isRaw, cell).HTMLIf(isRaw, cell)
-							})).
+							Children(crud.tableCells(r, columns, row, relationLabels, true)).
 							Child(
 								hb.TD().
 									Style(`white-space:nowrap;`).
-									ChildIf(crud.funcFetchReadData != nil, buttonView).
+									ChildIf(crud.isReadEnabled(), buttonView).
 									ChildIf(crud.funcFetchUpdateData != nil, buttonEdit).
+									ChildIf(crud.isDuplicateEnabled(), buttonDuplicate).
 									ChildIf(crud.funcTrash != nil, buttonTrash),
 							)
 						return tr
@@ -201,32 +287,80 @@ func (crud *Crud) pageEntityManager(w http.ResponseWriter, r *http.Request) {
 		return table
 	})
 
+	createFields := requestFields(r, crud.createFields)
+	customAttrValues, errDuplicate := crud.createValues(r, createFields, true)
+
 	container := hb.Div().
 		ID("entity-manager").
 		Class("container").
 		Child(heading).
 		Child(hb.Raw(breadcrumbs)).
-		Child(crud.pageEntitiesEntityCreateModal()).
+		ChildIf(errDuplicate != nil, hb.Div().
+			Class(crud.theme.AlertClass(ALERT_DANGER)).
+			Text(crud.t("The entity to duplicate could not be fetched"))).
+		Child(crud.pageEntitiesEntityCreateModal(r)).
 		Child(crud.pageEntitiesEntityTrashModal()).
+		Child(crud.filterBar(query, viewName, facets)).
 		Child(tableContent)
 
 	content := container.ToHTML()
 
-	urlEntityCreateAjax, _ := utils.ToJSON(crud.UrlEntityCreateAjax())
+	// a duplicate is saved with the ID of the entity being duplicated
+	createQuery := lo.Ternary(crud.duplicateID(r) == "", "", "duplicate="+url.QueryEscape(crud.duplicateID(r)))
+	urlEntityCreateAjax, _ := utils.ToJSON(appendQuery(crud.UrlEntityCreateAjax(), createQuery))
 	urlEntityTrashAjax, _ := utils.ToJSON(crud.UrlEntityTrashAjax())
-	urlEntityUpdate, _ := utils.ToJSON(crud.UrlEntityUpdate())
-
-	customAttrValues := map[string]string{}
-	lo.ForEach(crud.createFields, func(field FormField, index int) {
-		customAttrValues[field.Name] = field.Value
+	urlEntityFetchAjax, _ := utils.ToJSON(crud.UrlEntityFetchAjax())
+	urlEntityInlineUpdateAjax, _ := utils.ToJSON(crud.UrlEntityInlineUpdateAjax())
+	urlEntityManager, _ := utils.ToJSON(crud.UrlEntityManager())
+	urlEntityViewSaveAjax, _ := utils.ToJSON(crud.UrlEntityViewSaveAjax())
+	urlEntityViewDeleteAjax, _ := utils.ToJSON(crud.UrlEntityViewDeleteAjax())
+	urlEntityColumnsSaveAjax, _ := utils.ToJSON(crud.UrlEntityColumnsSaveAjax())
+	jsonColumns, _ := utils.ToJSON(crud.columnsConfig(columns))
+	jsonColumnsStorageKey, _ := utils.ToJSON(crud.preferenceKey("columns"))
+
+	// the names of the visible columns and the initial order of the
+	// table, by the sort of the query
+	visibleColumnNames := lo.Map(columns, func(index int, _ int) string {
+		return crud.columns[index].label()
 	})
-	jsonCustomValues, _ := utils.ToJSON(customAttrValues)
+	tableOrder := [][]any{{0, "asc"}}
+	if sortIndex := lo.IndexOf(visibleColumnNames, query.Sort); sortIndex >= 0 {
+		tableOrder = [][]any{{sortIndex, lo.Ternary(query.SortDescending, "desc", "asc")}}
+	}
+	jsonVisibleColumns, _ := utils.ToJSON(visibleColumnNames)
+	jsonTableOrder, _ := utils.ToJSON(tableOrder)
+	jsonView, _ := utils.ToJSON(map[string]any{
+		"name":      viewName,
+		"query":     rowsQueryParams(query).Encode(),
+		"isDefault": lo.SomeBy(crud.views(r), func(view View) bool { return view.IsDefault && view.Name == viewName }),
+	})
+
+	jsonCustomValues, _ := utils.ToJSON(formModel(createFields, customAttrValues))
+	jsonTmpValues, _ := utils.ToJSON(crud.formState(createFields, customAttrValues))
+	jsonDependentOptions, _ := utils.ToJSON(crud.dependentOptionsConfig(createFields, "create"))
+	jsonComputed, _ := utils.ToJSON(computedConfig(createFields))
 
-	inlineScript := `
+	inlineScript := scriptFormHelpers + `
 const entityCreateUrl = ` + urlEntityCreateAjax + `;
-const entityUpdateUrl = ` + urlEntityUpdate + `;
 const entityTrashUrl = ` + urlEntityTrashAjax + `;
+const entityFetchUrl = ` + urlEntityFetchAjax + `;
+const entityInlineUpdateUrl = ` + urlEntityInlineUpdateAjax + `;
+const entityManagerUrl = ` + urlEntityManager + `;
+const entityViewSaveUrl = ` + urlEntityViewSaveAjax + `;
+const entityViewDeleteUrl = ` + urlEntityViewDeleteAjax + `;
+const visibleColumns = ` + jsonVisibleColumns + `;
+const tableOrder = ` + jsonTableOrder + `;
+const currentView = ` + jsonView + `;
+const entityColumnsSaveUrl = ` + urlEntityColumnsSaveAjax + `;
+const columnsList = ` + jsonColumns + `;
+const columnsStorageKey = ` + jsonColumnsStorageKey + `;
+const columnsStore = ` + lo.Ternary(crud.isViewsEnabled(), "true", "false") + `;
+const isColumnsSet = ` + lo.Ternary(isColumnsSet, "true", "false") + `;
 const customValues = ` + jsonCustomValues + `;
+const tmpValues = ` + jsonTmpValues + `;
+const dependentOptions = ` + jsonDependentOptions + `;
+const computed = ` + jsonComputed + `;
+const openCreate = ` + lo.Ternary(crud.isCreateRequested(r) && errDuplicate == nil, "true", "false") + `;
 const EntityManager = {
 	data() {
 		return {
@@ -235,7 +369,25 @@ const EntityManager = {
 		  },
 		  entityTrashModel:{
 			entityId:null,
-		  }
+		  },
+		  inline:{
+			key:null,
+			entityId:null,
+			field:null,
+			value:"",
+			saving:false,
+			display:{},
+		  },
+		  tmp:{
+			...tmpValues
+		  },
+		  view:{
+			...currentView
+		  },
+		  columns:{
+			list:columnsList,
+			dragIndex:null,
+		  },
 		}
 	},
 	created(){
@@ -244,11 +396,119 @@ const EntityManager = {
 			this.initDataTable();
 		//}, 1000);
 	},
-	methods: {
+	mounted(){
+		this.optionsWatch(dependentOptions);
+		this.computedWatch(computed);
+		if (openCreate) this.showEntityCreateModal();
+		if (!columnsStore && !isColumnsSet) this.columnsRestore();
+	},
+	methods: {` + scriptFormMethods + `
 		initDataTable(){
 			$(() => {
-				$('#TableEntities').DataTable({
-					"order": [[ 0, "asc" ]] // 1st column
+				const table = $('#TableEntities').DataTable({
+					"order": tableOrder // 1st column, unless sorted by the view
+				});
+				table.on('order.dt', () => this.viewSort(table.order()[0]));
+			});
+		},
+		viewSort(order){
+			const name = visibleColumns[order[0]];
+			if (!name) return;
+			const params = new URLSearchParams(this.view.query);
+			params.set("sort", name);
+			params.set("sort_dir", order[1]);
+			this.view.query = params.toString();
+			history.replaceState(null, "", crudAppendQuery(entityManagerUrl, "view=" + encodeURIComponent(this.view.name) + "&" + this.view.query));
+		},
+		columnDragStart(index){
+			this.columns.dragIndex = index;
+		},
+		columnDrop(index){
+			const from = this.columns.dragIndex;
+			this.columns.dragIndex = null;
+			if (from === null || from === index) return;
+			const column = this.columns.list.splice(from, 1)[0];
+			this.columns.list.splice(index, 0, column);
+		},
+		columnsApply(reset){
+			const names = reset ? [] : this.columns.list.filter(column => column.visible).map(column => column.name);
+			if (!reset && names.length === 0) {
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: crudT('At least one column must be visible')});
+			}
+			if (!columnsStore) {
+				// without a preferences store the columns are kept by the browser
+				if (reset) localStorage.removeItem(columnsStorageKey);
+				else localStorage.setItem(columnsStorageKey, JSON.stringify(names));
+				return this.columnsShow(names);
+			}
+			$.post(entityColumnsSaveUrl, {columns: names}).done((response)=>{
+				if (response.status !== "success") {
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+				}
+				return this.columnsShow(names);
+			}).fail((result)=>{
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
+			});
+		},
+		columnsRestore(){
+			let names = [];
+			try {
+				names = JSON.parse(localStorage.getItem(columnsStorageKey)) || [];
+			} catch (e) {}
+			const known = this.columns.list.map(column => column.name);
+			names = names.filter(name => known.includes(name));
+			if (names.length > 0) this.columnsShow(names);
+		},
+		columnsShow(names){
+			const params = new URLSearchParams(this.view.query);
+			params.delete("columns");
+			names.forEach(name => params.append("columns", name));
+			const query = params.toString();
+			location.href = crudAppendQuery(entityManagerUrl, "view=" + encodeURIComponent(this.view.name) + (query === "" ? "" : "&" + query));
+		},
+		viewSave(){
+			Swal.fire({
+				title: crudT('Save view'),
+				html: '<input id="ViewName" class="swal2-input" placeholder="' + crudT('Name') + '">' +
+					'<label><input id="ViewDefault" type="checkbox" class="form-check-input me-2">' + crudT('Default view') + '</label>',
+				showCancelButton: true,
+				confirmButtonText: crudT('Save'),
+				onOpen: () => {
+					document.getElementById('ViewName').value = this.view.name;
+					document.getElementById('ViewDefault').checked = this.view.isDefault;
+				},
+				preConfirm: () => {
+					const name = document.getElementById('ViewName').value.trim();
+					if (name === "") return Swal.showValidationMessage(crudT('Name is required'));
+					return {name: name, default: document.getElementById('ViewDefault').checked ? "1" : "0"};
+				},
+			}).then((result) => {
+				if (!result.value) return;
+				$.post(entityViewSaveUrl, {...result.value, query: this.view.query}).done((response)=>{
+					if (response.status !== "success") {
+						return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+					}
+					return location.href = crudAppendQuery(entityManagerUrl, "view=" + encodeURIComponent(response.data.name));
+				}).fail((result)=>{
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
+				});
+			});
+		},
+		viewDelete(){
+			Swal.fire({
+				icon: 'warning',
+				titleText: crudT('Delete view "{name}"?', {name: this.view.name}),
+				showCancelButton: true,
+				confirmButtonText: crudT('Delete'),
+			}).then((result) => {
+				if (!result.value) return;
+				$.post(entityViewDeleteUrl, {name: this.view.name}).done((response)=>{
+					if (response.status !== "success") {
+						return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+					}
+					return location.href = crudAppendQuery(entityManagerUrl, "view=");
+				}).fail((result)=>{
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 				});
 			});
 		},
@@ -261,17 +521,55 @@ const EntityManager = {
 			const modalEntityDelete = new bootstrap.Modal(document.getElementById('ModalEntityTrash'));
 			modalEntityDelete.show();
 		},
+		inlineEdit(entityId, field, type){
+			const key = entityId + ":" + field;
+			if (this.inline.key === key) return;
+			$.post(entityFetchUrl, {entity_id: entityId}).done((response)=>{
+				if (response.status !== "success") {
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+				}
+				const value = response.data.model[field];
+				this.inline.value = value === null || value === undefined ? "" : String(value);
+				if (type === "datetime") this.inline.value = this.inline.value.replace(" ", "T");
+				this.inline.entityId = entityId;
+				this.inline.field = field;
+				this.inline.key = key;
+				this.$nextTick(() => {
+					const input = document.querySelector("#TableEntities .input-group input, #TableEntities .input-group select, #TableEntities .input-group textarea");
+					if (input) input.focus();
+				});
+			});
+		},
+		inlineCancel(){
+			this.inline.key = null;
+		},
+		inlineSave(){
+			const data = {entity_id: this.inline.entityId, field: this.inline.field};
+			data[this.inline.field] = this.inline.value;
+			this.inline.saving = true;
+			$.post(entityInlineUpdateUrl, data).done((response)=>{
+				if (response.status !== "success") {
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+				}
+				this.inline.display[this.inline.key] = response.data.display;
+				this.inline.key = null;
+			}).fail((result)=>{
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
+			}).always(()=>{
+				this.inline.saving = false;
+			});
+		},
 		entityCreate(){
-		    $.post(entityCreateUrl, this.entityModel).done((result)=>{
+		    $.post(entityCreateUrl, crudSerializeModel(this.entityModel)).done((result)=>{
 				if (result.status==="success"){
 					const modalEntityCreate = new bootstrap.Modal(document.getElementById('ModalEntityCreate'));
 			        modalEntityCreate.hide();
-					return location.href = entityUpdateUrl+ "&entity_id=" + result.data.entity_id;
+					return location.href = result.data.update_url;
 				}
 				
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result.message});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result.message});
 			}).fail((result)=>{
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 			});
 		},
 
@@ -282,27 +580,34 @@ const EntityManager = {
 				entity_id:entityId
 			}).done((response)=>{
 				if (response.status !== "success") {
-					return Swal.fire({icon: 'error', title: 'Oops...', text: result.message});
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result.message});
 				}
 
 				setTimeout(()=>{return location.href = location.href;}, 3000)
 
-				return Swal.fire({icon: 'success', title: 'Entity trashed'});
+				return Swal.fire({icon: 'success', title: crudT('Entity trashed')});
 			}).fail((result)=>{
 				console.log(result);
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 			});
 		}
 	}
 };
-Vue.createApp(EntityManager).mount('#entity-manager')
+const entityManagerApp = Vue.createApp(EntityManager);
+if (window.ElementPlus) entityManagerApp.use(ElementPlus);
+entityManagerApp.mount('#entity-manager')
 	`
-	title := crud.entityNameSingular + " Manager"
-	html := crud.layout(w, r, title, content, []string{
-		cdn.JqueryDataTablesCss_1_13_4(),
-	}, "html{width:100%;}", []string{
-		cdn.JqueryDataTablesJs_1_13_4(),
-	}, inlineScript)
+	styleFiles := []string{cdn.JqueryDataTablesCss_1_13_4()}
+	jsFiles := []string{cdn.JqueryDataTablesJs_1_13_4()}
+
+	// the date pickers of the create form
+	if crud.funcLayout == nil && lo.SomeBy(crud.createFields, FormField.isDateField) {
+		styleFiles = append(styleFiles, "https://unpkg.com/element-plus/dist/index.css")
+		jsFiles = append(jsFiles, "https://cdn.jsdelivr.net/npm/element-plus")
+	}
+
+	title := crud.tEntity("{name} Manager")
+	html := crud.layout(w, r, title, content, styleFiles, "html{width:100%;}", jsFiles, inlineScript)
 
 	w.WriteHeader(200)
 	w.Header().Set("Content-Type", "text/html")
@@ -312,45 +617,53 @@ Vue.createApp(EntityManager).mount('#entity-manager')
 func (crud *Crud) pageEntityRead(w http.ResponseWriter, r *http.Request) {
 	entityID := utils.Req(r, "entity_id", "")
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
 		return
 	}
 
-	if crud.funcFetchReadData == nil {
+	if !crud.isReadEnabled() {
 		api.Respond(w, r, api.Error("FuncFetchReadData is required"))
 		return
 	}
 
 	breadcrumbs := crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: crud.t("Home"),
 			URL:  crud.urlHome(),
 		},
 		{
-			Name: crud.entityNameSingular + " Manager",
+			Name: crud.tEntity("{name} Manager"),
 			URL:  crud.UrlEntityManager(),
 		},
 		{
-			Name: "View " + crud.entityNameSingular,
-			URL:  crud.UrlEntityUpdate() + "&entity_id=" + entityID,
+			Name: crud.tEntity("View {name}"),
+			URL:  crud.UrlEntityUpdateByID(entityID),
 		},
 	})
 
 	buttonEdit := hb.Hyperlink().
-		Class("btn btn-primary ml-2 float-end").
+		Class(crud.theme.ButtonClass(BUTTON_PRIMARY, false) + " ml-2 float-end").
 		Child(icons.Icon("bi-pencil-square", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Edit").
-		Href(crud.UrlEntityUpdate() + "&entity_id=" + entityID)
+		HTML(crud.t("Edit")).
+		Href(crud.UrlEntityUpdateByID(entityID))
+
+	buttonDuplicate := hb.Hyperlink().
+		Class(crud.theme.ButtonClass(BUTTON_SECONDARY, false) + " ml-2 float-end").
+		Style("margin-right:10px;").
+		Child(icons.Icon("bi-files", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
+		HTML(crud.t("Duplicate")).
+		Href(crud.UrlEntityDuplicate(entityID))
 
 	buttonCancel := hb.Hyperlink().
-		Class("btn btn-secondary ml-2 float-end").
+		Class(crud.theme.ButtonClass(BUTTON_SECONDARY, false) + " ml-2 float-end").
 		Child(icons.Icon("bi-chevron-left", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Back").
+		HTML(crud.t("Back")).
 		Href(crud.UrlEntityManager())
 
 	heading := hb.Heading1().
-		HTML("View " + crud.entityNameSingular).
+		HTML(crud.tEntity("View {name}")).
 		Child(buttonEdit).
+		ChildIf(crud.isDuplicateEnabled(), buttonDuplicate).
 		Child(buttonCancel)
 
 	container := hb.Div().
@@ -359,39 +672,18 @@ func (crud *Crud) pageEntityRead(w http.ResponseWriter, r *http.Request) {
 		Child(heading).
 		Child(hb.Raw(breadcrumbs))
 
-	data, err := crud.funcFetchReadData(entityID)
+	table := lo.IfF(len(crud.readFields) > 0, func() hb.TagInterface {
+		values, err := crud.fetchReadValues(entityID)
 
-	table := lo.IfF(err != nil, func() hb.TagInterface {
-		alert := hb.Div().
-			Class("alert alert-danger").
-			HTML("There was an error retrieving the data. Please try again later")
+		if err != nil {
+			return hb.Div().
+				Class(crud.theme.AlertClass(ALERT_DANGER)).
+				HTML(crud.t("There was an error retrieving the data. Please try again later"))
+		}
 
-		return alert
+		return hb.Wrap().Children(crud.readView(r, crud.displayDateValues(r, crud.readFields, values)))
 	}).ElseF(func() hb.TagInterface {
-		table := hb.Table().
-			Class("table table-hover table-striped").
-			Child(hb.Thead().Child(hb.TR())).
-			Child(hb.Tbody().Children(lo.Map(data, func(row [2]string, _ int) hb.TagInterface {
-				key := row[0]
-				value := row[1]
-				isRawKey := strings.HasPrefix(key, "{!!") && strings.HasSuffix(key, "!!}")
-				isRawValue := strings.HasPrefix(value, "{!!") && strings.HasSuffix(value, "!!}")
-
-				key = strings.ReplaceAll(key, "{!!", "")
-				key = strings.ReplaceAll(key, "!!}", "")
-				key = strings.TrimSpace(key)
-
-				value = strings.ReplaceAll(value, "{!!", "")
-				value = strings.ReplaceAll(value, "!!}", "")
-				value = strings.TrimSpace(value)
-
-				return hb.TR().Children([]hb.TagInterface{
-					hb.TH().TextIf(!isRawKey, key).HTMLIf(isRawKey, key),
-					hb.TD().TextIf(!isRawValue, value).HTMLIf(isRawValue, value),
-				})
-			})))
-
-		return table
+		return crud.readTable(entityID)
 	})
 
 	card := hb.Div().
@@ -401,7 +693,7 @@ func (crud *Crud) pageEntityRead(w http.ResponseWriter, r *http.Request) {
 				Class("card-header").
 				Style(`display:flex;justify-content:space-between;align-items:center;`).
 				Child(hb.Heading4().
-					HTML(crud.entityNameSingular + " Details").
+					HTML(crud.tEntity("{name} Details")).
 					Style("margin-bottom:0;display:inline-block;")).
 				Child(buttonEdit),
 		).
@@ -414,45 +706,83 @@ func (crud *Crud) pageEntityRead(w http.ResponseWriter, r *http.Request) {
 	if crud.funcReadExtras != nil {
 		container.Children(crud.funcReadExtras(entityID))
 	}
-	content := container.ToHTML()
-	title := "View " + crud.entityNameSingular
-	html := crud.layout(w, r, title, content, []string{}, "", []string{}, "")
+	childGrids, childScript := crud.childGrids(r, entityID)
+	content := container.ToHTML() + hb.Wrap().Children(childGrids).ToHTML()
+	inlineScript := lo.Ternary(childScript == "", "", scriptFormHelpers+childScript)
+	title := crud.tEntity("View {name}")
+	html := crud.layout(w, r, title, content, []string{}, "", []string{}, inlineScript)
 
 	w.WriteHeader(200)
 	w.Header().Set("Content-Type", "text/html")
 	w.Write([]byte(html))
 }
 
+// readTable generates the details of the entity from the key-value
+// pairs returned by FuncFetchReadData, used when there are no ReadFields
+func (crud *Crud) readTable(entityID string) hb.TagInterface {
+	data, err := crud.funcFetchReadData(entityID)
+
+	table := lo.IfF(err != nil, func() hb.TagInterface {
+		alert := hb.Div().
+			Class(crud.theme.AlertClass(ALERT_DANGER)).
+			HTML(crud.t("There was an error retrieving the data. Please try again later"))
+
+		return alert
+	}).ElseF(func() hb.TagInterface {
+		table := hb.Table().
+			Class("table table-hover table-striped").
+			Child(hb.Thead().Child(hb.TR())).
+			Child(hb.Tbody().Children(lo.Map(data, func(row [2]string, _ int) hb.TagInterface {
+				key := row[0]
+				value := row[1]
+				isRawKey := isRawMarked(key)
+				isRawValue := isRawMarked(value)
+
+				key = stripRawMarkers(key)
+				value = stripRawMarkers(value)
+
+				return hb.TR().Children([]hb.TagInterface{
+					hb.TH().TextIf(!isRawKey, key).HTMLIf(isRawKey, key),
+					hb.TD().TextIf(!isRawValue, value).HTMLIf(isRawValue, value),
+				})
+			})))
+
+		return table
+	})
+
+	return table
+}
+
 func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 	entityID := utils.Req(r, "entity_id", "")
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
 		return
 	}
 
 	breadcrumbs := crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: crud.t("Home"),
 			URL:  crud.urlHome(),
 		},
 		{
-			Name: crud.entityNameSingular + " Manager",
+			Name: crud.tEntity("{name} Manager"),
 			URL:  crud.UrlEntityManager(),
 		},
 		{
-			Name: "Edit " + crud.entityNameSingular,
-			URL:  crud.UrlEntityUpdate() + "&entity_id=" + entityID,
+			Name: crud.tEntity("Edit {name}"),
+			URL:  crud.UrlEntityUpdateByID(entityID),
 		},
 	})
 
-	buttonSave := hb.Button().Class("btn btn-success float-end").Attr("v-on:click", "entitySave(true)").
+	buttonSave := hb.Button().Class(crud.theme.ButtonClass(BUTTON_SUCCESS, false)+" float-end").Attr("v-on:click", "entitySave(true)").
 		AddChild(icons.Icon("bi-check-all", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Save")
-	buttonApply := hb.Button().Class("btn btn-success float-end").Attr("v-on:click", "entitySave").
+		HTML(crud.t("Save"))
+	buttonApply := hb.Button().Class(crud.theme.ButtonClass(BUTTON_SUCCESS, false)+" float-end").Attr("v-on:click", "entitySave").
 		Style("margin-right:10px;").
 		AddChild(icons.Icon("bi-check", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Apply")
-	heading := hb.Heading1().Text("Edit " + crud.entityNameSingular).
+		HTML(crud.t("Apply"))
+	heading := hb.Heading1().Text(crud.tEntity("Edit {name}")).
 		AddChild(buttonSave).
 		AddChild(buttonApply)
 
@@ -464,26 +794,37 @@ func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 	customAttrValues, errData := crud.funcFetchUpdateData(entityID)
 
 	if errData != nil {
-		api.Respond(w, r, api.Error("Fetch data failed"))
+		api.Respond(w, r, api.Error(crud.t("Fetch data failed")))
 		return
 	}
 
-	container.AddChildren(crud.form(crud.updateFields))
+	updateFields := requestFields(r, crud.updateFields)
+	customAttrValues = visibleValues(r, crud.updateFields, customAttrValues)
+	customAttrValues = crud.displayDateValues(r, updateFields, customAttrValues)
 
-	content := container.ToHTML()
+	container.AddChildren(crud.form(updateFields))
+
+	childGrids, childScript := crud.childGrids(r, entityID)
+	content := container.ToHTML() + hb.Wrap().Children(childGrids).ToHTML()
 
-	jsonCustomValues, _ := utils.ToJSON(customAttrValues)
+	jsonCustomValues, _ := utils.ToJSON(formModel(updateFields, customAttrValues))
+	jsonTmpValues, _ := utils.ToJSON(crud.formState(updateFields, customAttrValues))
+	jsonDependentOptions, _ := utils.ToJSON(crud.dependentOptionsConfig(updateFields, "update"))
+	jsonComputed, _ := utils.ToJSON(computedConfig(updateFields))
 
 	urlHome, _ := utils.ToJSON(crud.endpoint)
 	urlEntityTrashAjax, _ := utils.ToJSON(crud.UrlEntityTrashAjax())
 	urlEntityUpdateAjax, _ := utils.ToJSON(crud.UrlEntityUpdateAjax())
 
-	inlineScript := `
+	inlineScript := scriptFormHelpers + `
 	const entityManagerUrl = ` + urlHome + `;
 	const entityUpdateUrl = ` + urlEntityUpdateAjax + `;
 	const entityTrashUrl = ` + urlEntityTrashAjax + `;
 	const entityId = "` + entityID + `";
 	const customValues = ` + jsonCustomValues + `;
+	const tmpValues = ` + jsonTmpValues + `;
+	const dependentOptions = ` + jsonDependentOptions + `;
+	const computed = ` + jsonComputed + `;
 	const EntityUpdate = {
 		data() {
 			return {
@@ -491,7 +832,9 @@ func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 					entityId,
 					...customValues
 			    },
-				tmp:{},
+				tmp:{
+					...tmpValues
+				},
 				trumbowigConfig: {
 					btns: [
 						['undo', 'redo'], 
@@ -512,16 +855,20 @@ func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 				},
 			}
 		},
-		methods: {
+		mounted(){
+			this.optionsWatch(dependentOptions);
+			this.computedWatch(computed);
+		},
+		methods: {` + scriptFormMethods + `
 			entitySave(redirect){
 				const entityId = this.entityModel.entityId;
 				let data = JSON.parse(JSON.stringify(this.entityModel));
 				data["entity_id"] = data["entityId"];
 				delete data["entityId"];
 
-				$.post(entityUpdateUrl, data).done((response)=>{
+				$.post(entityUpdateUrl, crudSerializeModel(data)).done((response)=>{
 					if (response.status !== "success") {
-						return Swal.fire({icon: 'error', title: 'Oops...', text: response.message});
+						return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
 					}
 
 					if (redirect===true) {
@@ -530,10 +877,10 @@ func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 						}, 3000)
 					}
 
-					return Swal.fire({icon: 'success',title: 'Entity saved'});
+					return Swal.fire({icon: 'success',title: crudT('Entity saved')});
 				}).fail((result)=>{
 					console.log(result);
-					return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 				});
 			},
 			uploadImage(event, fieldName) {
@@ -550,12 +897,11 @@ func (crud *Crud) pageEntityUpdate(w http.ResponseWriter, r *http.Request) {
 		}
 	};
 	Vue.createApp(EntityUpdate).use(ElementPlus).component('Trumbowyg', VueTrumbowyg.default).mount('#entity-update')
-		`
+		` + childScript
 
-	// webpage := crud.webpage("Edit "+crud.entityNameSingular, h)
 	// webpage.AddScript(inlineScript)
 
-	title := "Edit " + crud.entityNameSingular
+	title := crud.tEntity("Edit {name}")
 	html := crud.layout(w, r, title, content, []string{
 		cdn.JqueryDataTablesCss_1_13_4(),
 		cdn.TrumbowygCss_2_27_3(),
@@ -575,100 +921,173 @@ func (crud *Crud) pageEntityUpdateAjax(w http.ResponseWriter, r *http.Request) {
 	entityID := strings.Trim(utils.Req(r, "entity_id", ""), " ")
 
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
 		return
 	}
 
-	names := crud.listUpdateNames()
+	// Only the posted fields are updated, the absent ones are kept
+	names := crud.listUpdateNames(r)
 	posts := map[string]string{}
 	for _, name := range names {
-		posts[name] = utils.Req(r, name, "")
+		field, _ := fieldByName(crud.updateFields, name)
+		if isPosted(r, field) {
+			posts[name] = requestValue(r, field)
+		}
 	}
 
-	// Check required fields
-	for _, field := range crud.updateFields {
-		if !field.Required {
-			continue
-		}
+	if errorMessage := crud.updateEntity(r, entityID, editableFields(r, crud.updateFields), posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
 
-		if _, exists := posts[field.Name]; !exists {
-			api.Respond(w, r, api.Error(field.Label+" is required field"))
-			return
-		}
+	api.Respond(w, r, api.SuccessWithData(crud.t("Saved successfully"), map[string]interface{}{"entity_id": entityID}))
+}
 
-		if lo.IsEmpty(posts[field.Name]) {
-			api.Respond(w, r, api.Error(field.Label+" is required field"))
-			return
-		}
+func (crud *Crud) pageEntityTrashAjax(w http.ResponseWriter, r *http.Request) {
+	entityID := strings.Trim(utils.Req(r, "entity_id", ""), " ")
+
+	if entityID == "" {
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
+		return
 	}
 
-	err := crud.funcUpdate(entityID, posts)
+	err := crud.funcTrash(entityID)
 
 	if err != nil {
-		api.Respond(w, r, api.Error("Save failed: "+err.Error()))
+		api.Respond(w, r, api.Error(crud.t("Entity failed to be trashed: {error}", "error", err.Error())))
 		return
 	}
 
-	api.Respond(w, r, api.SuccessWithData("Saved successfully", map[string]interface{}{"entity_id": entityID}))
+	api.Respond(w, r, api.SuccessWithData(crud.t("Entity trashed successfully"), map[string]interface{}{"entity_id": entityID}))
 }
 
-func (crud *Crud) pageEntityTrashAjax(w http.ResponseWriter, r *http.Request) {
+// isReadEnabled returns true if the read page is available, either
+// driven by the ReadFields or by the key-value pairs of FuncFetchReadData
+func (crud *Crud) isReadEnabled() bool {
+	if len(crud.readFields) > 0 {
+		return crud.funcFetchReadValues != nil || crud.funcFetchUpdateData != nil
+	}
+
+	return crud.funcFetchReadData != nil
+}
+
+// fetchReadValues fetches the values of the entity for the read page,
+// using FuncFetchUpdateData if FuncFetchReadValues is not set
+func (crud *Crud) fetchReadValues(entityID string) (map[string]string, error) {
+	if crud.funcFetchReadValues != nil {
+		return crud.funcFetchReadValues(entityID)
+	}
+
+	return crud.funcFetchUpdateData(entityID)
+}
+
+// pageEntityFetchAjax returns the data of the entity as a form model,
+// used by the edit modal of the child entity grids
+func (crud *Crud) pageEntityFetchAjax(w http.ResponseWriter, r *http.Request) {
 	entityID := strings.Trim(utils.Req(r, "entity_id", ""), " ")
 
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
 		return
 	}
 
-	err := crud.funcTrash(entityID)
+	if crud.funcFetchUpdateData == nil {
+		api.Respond(w, r, api.Error("FuncFetchUpdateData is required"))
+		return
+	}
+
+	data, err := crud.funcFetchUpdateData(entityID)
 
 	if err != nil {
-		api.Respond(w, r, api.Error("Entity failed to be trashed: "+err.Error()))
+		api.Respond(w, r, api.Error(crud.t("Fetch data failed")))
 		return
 	}
 
-	api.Respond(w, r, api.SuccessWithData("Entity trashed successfully", map[string]interface{}{"entity_id": entityID}))
+	fields := requestFields(r, crud.updateFields)
+	data = visibleValues(r, crud.updateFields, data)
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("Data fetched successfully"), map[string]interface{}{
+		"entity_id": entityID,
+		"model":     formModel(fields, crud.displayDateValues(r, fields, data)),
+		"tmp":       crud.formState(fields, data),
+	}))
 }
 
-func (crud *Crud) pageEntitiesEntityTrashModal() hb.TagInterface {
-	modal := hb.Div().ID("ModalEntityTrash").Class("modal fade")
-	modalDialog := hb.Div().Attr("class", "modal-dialog")
-	modalContent := hb.Div().Attr("class", "modal-content")
-	modalHeader := hb.Div().Attr("class", "modal-header").AddChild(hb.Heading5().Text("Trash Entity"))
-	modalBody := hb.Div().Attr("class", "modal-body")
-	modalBody.AddChild(hb.Paragraph().Text("Are you sure you want to move this entity to trash bin?"))
-	modalFooter := hb.Div().Attr("class", "modal-footer")
-	modalFooter.AddChild(hb.Button().Text("Close").Attr("class", "btn btn-secondary").Attr("data-bs-dismiss", "modal"))
-	modalFooter.AddChild(hb.Button().Text("Move to trash bin").Attr("class", "btn btn-danger").Attr("v-on:click", "entityTrash"))
-	modalContent.AddChild(modalHeader).AddChild(modalBody).AddChild(modalFooter)
-	modalDialog.AddChild(modalContent)
-	modal.AddChild(modalDialog)
-	return modal
-}
-
-func (crud *Crud) pageEntitiesEntityCreateModal() hb.TagInterface {
-	form := crud.form(crud.createFields)
-
-	modalHeader := hb.Div().Class("modal-header").
-		AddChild(hb.Heading5().Text("New " + crud.entityNameSingular))
-
-	modalBody := hb.Div().Class("modal-body").AddChildren(form)
-
-	modalFooter := hb.Div().Class("modal-footer").
-		AddChild(hb.Button().Text("Close").Class("btn btn-secondary").Attr("data-bs-dismiss", "modal")).
-		AddChild(hb.Button().Text("Create & Continue").Class("btn btn-primary").Attr("v-on:click", "entityCreate"))
-
-	modal := hb.Div().ID("ModalEntityCreate").Class("modal fade").AddChildren([]hb.TagInterface{
-		hb.Div().Class("modal-dialog").AddChildren([]hb.TagInterface{
-			hb.Div().Class("modal-content").AddChildren([]hb.TagInterface{
-				modalHeader,
-				modalBody,
-				modalFooter,
-			}),
+// pageEntitySearchAjax returns a page of the entities matching the
+// search query, used by the relation fields of other entities
+func (crud *Crud) pageEntitySearchAjax(w http.ResponseWriter, r *http.Request) {
+	if crud.funcSearch == nil {
+		api.Respond(w, r, api.Error("FuncSearch is required"))
+		return
+	}
+
+	query := strings.TrimSpace(utils.Req(r, "q", ""))
+	page, err := utils.ToInt(utils.Req(r, "page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	options, hasMore, err := crud.funcSearch(query, int(page))
+
+	if err != nil {
+		api.Respond(w, r, api.Error(crud.t("Search failed: {error}", "error", err.Error())))
+		return
+	}
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("Search completed"), map[string]interface{}{
+		"options": lo.Map(options, func(option FormFieldOption, _ int) map[string]string {
+			return map[string]string{"key": option.Key, "value": option.Value}
 		}),
+		"page":     page,
+		"has_more": hasMore,
+	}))
+}
+
+// pageEntityOptionsAjax returns the options of a field with dependent
+// options for the posted values of the form
+func (crud *Crud) pageEntityOptionsAjax(w http.ResponseWriter, r *http.Request) {
+	fields := lo.Ternary(utils.Req(r, "form", "") == "update", crud.updateFields, crud.createFields)
+	field, found := fieldByName(fields, utils.Req(r, "field", ""))
+
+	if !found || !field.hasDependentOptions() {
+		api.Respond(w, r, api.Error(crud.t("Field not found")))
+		return
+	}
+
+	options := field.DependentOptionsF(formValues(r, fields))
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("Options fetched successfully"), map[string]interface{}{
+		"options": optionsJSON(crud.translateOptions(options)),
+	}))
+}
+
+func (crud *Crud) pageEntitiesEntityTrashModal() hb.TagInterface {
+	return crud.theme.Modal(ThemeModal{
+		ID:    "ModalEntityTrash",
+		Title: crud.t("Trash Entity"),
+		Body: []hb.TagInterface{
+			hb.Paragraph().Text(crud.t("Are you sure you want to move this entity to trash bin?")),
+		},
+		Footer: []hb.TagInterface{
+			hb.Button().Text(crud.t("Close")).Class(crud.theme.ButtonClass(BUTTON_SECONDARY, false)).Attr("data-bs-dismiss", "modal"),
+			hb.Button().Text(crud.t("Move to trash bin")).Class(crud.theme.ButtonClass(BUTTON_DANGER, false)).Attr("v-on:click", "entityTrash"),
+		},
 	})
+}
+
+func (crud *Crud) pageEntitiesEntityCreateModal(r *http.Request) hb.TagInterface {
+	fields := requestFields(r, crud.createFields)
 
-	return modal
+	return crud.theme.Modal(ThemeModal{
+		ID:    "ModalEntityCreate",
+		Title: crud.tEntity("New {name}"),
+		Body:  crud.form(fields),
+		Footer: []hb.TagInterface{
+			hb.Button().Text(crud.t("Close")).Class(crud.theme.ButtonClass(BUTTON_SECONDARY, false)).Attr("data-bs-dismiss", "modal"),
+			hb.Button().Text(crud.t("Create & Continue")).Class(crud.theme.ButtonClass(BUTTON_PRIMARY, false)).Attr("v-on:click", "entityCreate"),
+		},
+		Large: isWideForm(fields),
+	})
 }
 
 func (crud *Crud) urlHome() string {
@@ -677,17 +1096,40 @@ func (crud *Crud) urlHome() string {
 }
 
 func (crud *Crud) UrlEntityManager() string {
+	if crud.pathRouting {
+		return crud.endpoint
+	}
+
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityManager
 	return url
 }
 
+func (crud *Crud) UrlEntityColumnsSaveAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityColumnsSaveAjax
+	return url
+}
+
 func (crud *Crud) UrlEntityCreateAjax() string {
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityCreateAjax
 	return url
 }
 
+// UrlEntityDuplicate returns the URL of the entity manager opening the
+// create form, prefilled with the values of the entity
+func (crud *Crud) UrlEntityDuplicate(entityID string) string {
+	return appendQuery(crud.UrlEntityManager(), "create=1&"+encodeQuery(map[string]string{"duplicate": entityID}))
+}
+
+// UrlEntityCreate returns the URL of the entity manager opening the
+// create form, prefilled with the values, e.g. to create a child entity
+// from the page of the parent
+func (crud *Crud) UrlEntityCreate(values map[string]string) string {
+	return appendQuery(crud.UrlEntityManager(), "create=1"+lo.Ternary(len(values) == 0, "", "&"+encodeQuery(values)))
+}
+
 func (crud *Crud) UrlEntityTrashAjax() string {
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityTrashAjax
@@ -706,63 +1148,59 @@ func (crud *Crud) UrlEntityUpdate() string {
 	return url
 }
 
+func (crud *Crud) UrlEntityInlineUpdateAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityInlineUpdateAjax
+	return url
+}
+
+// UrlEntityExport returns the URL of the CSV export of the rows
+func (crud *Crud) UrlEntityExport() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityExport
+	return url
+}
+
+func (crud *Crud) UrlEntityFetchAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityFetchAjax
+	return url
+}
+
+func (crud *Crud) UrlEntitySearchAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntitySearchAjax
+	return url
+}
+
+func (crud *Crud) UrlEntityMarkdownPreviewAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityMarkdownPreviewAjax
+	return url
+}
+
+func (crud *Crud) UrlEntityOptionsAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityOptionsAjax
+	return url
+}
+
 func (crud *Crud) UrlEntityUpdateAjax() string {
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityUpdateAjax
 	return url
 }
 
-// Webpage returns the webpage template for the website
-func (crud *Crud) webpage(title, content string) *hb.HtmlWebpage {
-	faviconImgCms := `data:image/x-icon;base64,AAABAAEAEBAQAAEABAAoAQAAFgAAACgAAAAQAAAAIAAAAAEABAAAAAAAgAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAmzKzAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABEQEAAQERAAEAAQABAAEAAQABAQEBEQABAAEREQEAAAERARARAREAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD//wAA//8AAP//AAD//wAA//8AAP//AAD//wAAi6MAALu7AAC6owAAuC8AAIkjAAD//wAA//8AAP//AAD//wAA`
-	app := ""
-	webpage := hb.Webpage()
-	webpage.SetTitle(title)
-	webpage.SetFavicon(faviconImgCms)
+func (crud *Crud) UrlEntityViewDeleteAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityViewDeleteAjax
+	return url
+}
 
-	webpage.AddStyleURLs([]string{
-		"https://cdn.jsdelivr.net/npm/bootstrap@5.0.0-beta3/dist/css/bootstrap.min.css",
-	})
-	webpage.AddScriptURLs([]string{
-		"https://cdn.jsdelivr.net/npm/bootstrap@5.0.0-beta3/dist/js/bootstrap.bundle.min.js",
-		"https://code.jquery.com/jquery-3.6.0.min.js",
-		"https://unpkg.com/vue@3/dist/vue.global.js",
-		"https://cdn.jsdelivr.net/npm/sweetalert2@9",
-	})
-	webpage.AddScripts([]string{
-		app,
-	})
-	webpage.AddStyle(`html,body{height:100%;font-family: Ubuntu, sans-serif;}`)
-	webpage.AddStyle(`body {
-		font-family: "Nunito", sans-serif;
-		font-size: 0.9rem;
-		font-weight: 400;
-		line-height: 1.6;
-		color: #212529;
-		text-align: left;
-		background-color: #f8fafc;
-	}
-	.form-select {
-		display: block;
-		width: 100%;
-		padding: .375rem 2.25rem .375rem .75rem;
-		font-size: 1rem;
-		font-weight: 400;
-		line-height: 1.5;
-		color: #212529;
-		background-color: #fff;
-		background-image: url("data:image/svg+xml,%3csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 16 16'%3e%3cpath fill='none' stroke='%23343a40' stroke-linecap='round' stroke-linejoin='round' stroke-width='2' d='M2 5l6 6 6-6'/%3e%3c/svg%3e");
-		background-repeat: no-repeat;
-		background-position: right .75rem center;
-		background-size: 16px 12px;
-		border: 1px solid #ced4da;
-		border-radius: .25rem;
-		-webkit-appearance: none;
-		-moz-appearance: none;
-		appearance: none;
-	}`)
-	webpage.Child(hb.Raw(content))
-	return webpage
+func (crud *Crud) UrlEntityViewSaveAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityViewSaveAjax
+	return url
 }
 
 func (crud *Crud) _breadcrumbs(breadcrumbs []Breadcrumb) string {
@@ -800,26 +1238,40 @@ func (crud *Crud) _breadcrumbs(breadcrumbs []Breadcrumb) string {
 func (crud *Crud) layout(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string {
 	html := ""
 
+	if crud.admin != nil {
+		content = crud.admin.wrap(r, crud, content)
+	}
+
+	js = crud.scriptTranslations() + js
+
 	if crud.funcLayout != nil {
 		// jsFiles = append([]string{"//unpkg.com/naive-ui"}, jsFiles...)
 		jsFiles = append([]string{"//cdn.jsdelivr.net/npm/element-plus"}, jsFiles...)
 		jsFiles = append([]string{"https://unpkg.com/vue@3/dist/vue.global.js"}, jsFiles...)
 		jsFiles = append([]string{"https://cdn.jsdelivr.net/npm/sweetalert2@9"}, jsFiles...)
 		styleFiles = append([]string{"https://unpkg.com/element-plus/dist/index.css"}, styleFiles...)
-		html = crud.funcLayout(w, r, title, content, styleFiles, style, jsFiles, js)
+		html = crud.funcLayout(w, r, title, content, styleFiles, crud.theme.Style()+style, jsFiles, crud.theme.Script()+js)
 	} else {
-		webpage := crud.webpage(title, content)
-		webpage.AddStyleURLs(styleFiles)
-		webpage.AddStyle(style)
-		webpage.AddScriptURLs(jsFiles)
-		webpage.AddScript(js)
-		html = webpage.ToHTML()
+		html = crud.theme.Page(ThemePage{
+			Request:   r,
+			Title:     title,
+			Content:   content,
+			StyleURLs: styleFiles,
+			Style:     style,
+			ScriptURLs: append([]string{
+				"https://code.jquery.com/jquery-3.6.0.min.js",
+				"https://unpkg.com/vue@3/dist/vue.global.js",
+				"https://cdn.jsdelivr.net/npm/sweetalert2@9",
+			}, jsFiles...),
+			Script: js,
+		})
 	}
 
 	return html
 }
 
-// form generates a form with entries for each form field.
+// form generates a form with entries for each form field,
+// laid out in the field groups.
 //
 // Parameters:
 // - fields: a slice of FormField structs representing the fields in the form.
@@ -827,8 +1279,27 @@ func (crud *Crud) layout(w http.ResponseWriter, r *http.Request, title string, c
 // Returns:
 // - a slice of hb.Tags representing the form.
 func (crud *Crud) form(fields []FormField) []hb.TagInterface {
+	return crud.formLayout(fields)
+}
+
+// formFields generates the form groups of the fields, each in
+// a grid column with the width of the field.
+//
+// Parameters:
+// - fields: a slice of FormField structs representing the fields in the form.
+//
+// Returns:
+// - a slice of hb.Tags representing the columns.
+func (crud *Crud) formFields(fields []FormField) []hb.TagInterface {
 	tags := []hb.TagInterface{}
 	for _, field := range fields {
+		// hidden fields keep their value in the model, without an input
+		if field.Type == FORM_FIELD_TYPE_HIDDEN {
+			continue
+		}
+
+		field = crud.translateField(field)
+
 		fieldID := field.ID
 		if fieldID == "" {
 			fieldID = "id_" + utils.StrRandomFromGamma(32, "abcdefghijklmnopqrstuvwxyz1234567890")
@@ -840,18 +1311,13 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 			fieldLabel = fieldName
 		}
 
-		formGroup := hb.Div().Class("form-group mt-3")
-
 		formGroupLabel := hb.Label().
 			Text(fieldLabel).
 			Class("form-label").
-			ChildIf(
-				field.Required,
-				hb.Sup().Text("*").Class("text-danger ml-1"),
-			)
+			Child(requiredMarker(field))
 
 		formGroupInput := hb.Input().
-			Class("form-control").
+			Class(crud.theme.InputClass(field.Type)).
 			Attr("v-model", "entityModel."+fieldName)
 
 		if field.Type == FORM_FIELD_TYPE_IMAGE {
@@ -860,9 +1326,9 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 					Attr(`v-bind:src`, `entityModel.`+fieldName+`||'https://www.freeiconspng.com/uploads/no-image-icon-11.PNG'`).
 					Style(`width:200px;`),
 				bs.InputGroup().Children([]hb.TagInterface{
-					hb.Input().Type(hb.TYPE_URL).Class("form-control").Attr("v-model", "entityModel."+fieldName),
+					hb.Input().Type(hb.TYPE_URL).Class(crud.theme.InputClass(field.Type)).Attr("v-model", "entityModel."+fieldName),
 					hb.If(crud.fileManagerURL != "", bs.InputGroupText().Children([]hb.TagInterface{
-						hb.Hyperlink().Text("Browse").Href(crud.fileManagerURL).Target("_blank"),
+						hb.Hyperlink().Text(crud.t("Browse")).Href(crud.fileManagerURL).Target("_blank"),
 					})),
 				}),
 			})
@@ -879,11 +1345,11 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 						Attr("v-on:change", "uploadImage($event, '"+fieldName+"')").
 						Attr("accept", "image/*"),
 					hb.Button().
-						HTML("See Image Data").
+						HTML(crud.t("See Image Data")).
 						Attr("v-on:click", "tmp.show_url_"+fieldName+" = !tmp.show_url_"+fieldName),
 					hb.TextArea().
 						Type(hb.TYPE_URL).
-						Class("form-control").
+						Class(crud.theme.InputClass(field.Type)).
 						Attr("v-if", "tmp.show_url_"+fieldName).
 						Attr("v-model", "entityModel."+fieldName),
 				})
@@ -891,12 +1357,29 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 
 		if field.Type == FORM_FIELD_TYPE_DATETIME {
 			// formGroupInput = hb.Input().Type(hb.TYPE_DATETIME).Class("form-control").Attr("v-model", "entityModel."+fieldName)
-			formGroupInput = hb.NewTag(`el-date-picker`).Attr("type", "datetime").Attr("v-model", "entityModel."+fieldName)
+			formGroupInput = hb.NewTag(`el-date-picker`).Attr("type", "datetime").Attr("value-format", "YYYY-MM-DD HH:mm:ss").Attr("v-model", "entityModel."+fieldName)
 			// formGroupInput = hb.Tag(`n-date-picker`).Attr("type", "datetime").Class("form-control").Attr("v-model", "entityModel."+fieldName)
 		}
 
+		if field.Type == FORM_FIELD_TYPE_DATE {
+			formGroupInput = hb.NewTag(`el-date-picker`).Attr("type", "date").Attr("value-format", "YYYY-MM-DD").Attr("v-model", "entityModel."+fieldName)
+		}
+
+		if field.Type == FORM_FIELD_TYPE_DATERANGE {
+			formGroupInput = hb.NewTag(`el-date-picker`).
+				Attr("type", "daterange").
+				Attr("value-format", "YYYY-MM-DD").
+				Attr("start-placeholder", crud.t("Start date")).
+				Attr("end-placeholder", crud.t("End date")).
+				Attr("v-model", "entityModel."+fieldName)
+		}
+
+		if field.Type == FORM_FIELD_TYPE_TIME {
+			formGroupInput = hb.NewTag(`el-time-picker`).Attr("value-format", "HH:mm:ss").Attr("v-model", "entityModel."+fieldName)
+		}
+
 		if field.Type == FORM_FIELD_TYPE_HTMLAREA {
-			formGroupInput = hb.NewTag("trumbowyg").Attr("v-model", "entityModel."+fieldName).Attr(":config", "trumbowigConfig").Class("form-control")
+			formGroupInput = hb.NewTag("trumbowyg").Attr("v-model", "entityModel."+fieldName).Attr(":config", "trumbowigConfig").Class(crud.theme.InputClass(field.Type))
 		}
 
 		if field.Type == FORM_FIELD_TYPE_NUMBER {
@@ -908,7 +1391,7 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 		}
 
 		if field.Type == FORM_FIELD_TYPE_SELECT {
-			formGroupInput = hb.Select().Class("form-select").Attr("v-model", "entityModel."+fieldName)
+			formGroupInput = hb.Select().Class(crud.theme.InputClass(field.Type)).Attr("v-model", "entityModel."+fieldName)
 			for _, opt := range field.Options {
 				option := hb.Option().Value(opt.Key).Text(opt.Value)
 				formGroupInput.AddChild(option)
@@ -919,14 +1402,150 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 					formGroupInput.AddChild(option)
 				}
 			}
+			if field.hasDependentOptions() {
+				formGroupInput.AddChild(dependentOptionsTag(field))
+			}
+		}
+
+		if field.Type == FORM_FIELD_TYPE_MULTISELECT {
+			formGroupInput = hb.Select().Class(crud.theme.InputClass(field.Type)).Attr("multiple", "multiple").Attr("v-model", "entityModel."+fieldName)
+			for _, opt := range field.options() {
+				option := hb.Option().Value(opt.Key).Text(opt.Value)
+				formGroupInput.AddChild(option)
+			}
+			if field.hasDependentOptions() {
+				formGroupInput.AddChild(dependentOptionsTag(field))
+			}
+		}
+
+		if field.Type == FORM_FIELD_TYPE_CHECKBOXES || field.Type == FORM_FIELD_TYPE_RADIO {
+			inputType := lo.Ternary(field.Type == FORM_FIELD_TYPE_RADIO, hb.TYPE_RADIO, hb.TYPE_CHECKBOX)
+			formGroupInput = hb.Div()
+			for index, opt := range field.options() {
+				optionID := fieldID + "_" + utils.ToString(index)
+				formGroupInput.AddChild(hb.Div().Class("form-check").Children([]hb.TagInterface{
+					hb.Input().
+						Type(inputType).
+						Class("form-check-input").
+						ID(optionID).
+						Value(opt.Key).
+						Attr("v-model", "entityModel."+fieldName),
+					hb.Label().
+						Class("form-check-label").
+						Attr("for", optionID).
+						Text(opt.Value),
+				}))
+			}
+		}
+
+		if field.isBoolean() {
+			checkboxID := fieldID + "_input"
+			formGroupInput = hb.Div().
+				Class("form-check").
+				ClassIf(field.Type == FORM_FIELD_TYPE_SWITCH, "form-switch").
+				Children([]hb.TagInterface{
+					hb.Input().
+						Type(hb.TYPE_CHECKBOX).
+						Class("form-check-input").
+						ID(checkboxID).
+						Attr("true-value", "1").
+						Attr("false-value", "0").
+						Attr("v-model", "entityModel."+fieldName),
+					hb.Label().
+						Class("form-check-label").
+						Attr("for", checkboxID).
+						Text(fieldLabel).
+						Child(requiredMarker(field)),
+				})
+		}
+
+		if field.Type == FORM_FIELD_TYPE_TAGS {
+			formGroupInput = hb.Div().Children([]hb.TagInterface{
+				hb.Div().Class("mb-1").Child(
+					hb.Span().
+						Class("badge bg-secondary me-1").
+						Attr("v-for", "(tag, index) in entityModel."+fieldName).
+						Attr("v-bind:key", "tag").
+						Child(hb.Span().Text("{{ tag }}")).
+						Child(hb.Button().
+							Type(hb.TYPE_BUTTON).
+							Class("btn-close btn-close-white ms-1").
+							Style("font-size:0.6rem;").
+							Attr("v-on:click", "tagRemove('"+fieldName+"', index)")),
+				),
+				hb.Input().
+					Type(hb.TYPE_TEXT).
+					Class(crud.theme.InputClass(field.Type)).
+					Attr("placeholder", crud.t("Type and press Enter")).
+					Attr("v-on:keydown.enter.prevent", "tagAdd('"+fieldName+"', $event)"),
+			})
+		}
+
+		if field.Type == FORM_FIELD_TYPE_RELATION && field.Relation != nil {
+			state := "tmp.relation_" + fieldName
+			searchURL, _ := utils.ToJSON(field.Relation.UrlEntitySearchAjax())
+			searchURL = strings.ReplaceAll(searchURL, `"`, `'`)
+			formGroupInput = hb.Div().Class("position-relative").Children([]hb.TagInterface{
+				bs.InputGroup().Children([]hb.TagInterface{
+					hb.Input().
+						Type(hb.TYPE_TEXT).
+						Class(crud.theme.InputClass(field.Type)).
+						Attr("v-model", state+".query").
+						Attr("v-bind:placeholder", state+".label || "+crud.tJS("Type to search...")).
+						Attr("v-on:input", "relationSearch('"+fieldName+"', "+searchURL+", 1)").
+						Attr("v-on:focus", "relationSearch('"+fieldName+"', "+searchURL+", 1)"),
+					hb.Button().
+						Type(hb.TYPE_BUTTON).
+						Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)).
+						Attr("v-if", "entityModel."+fieldName).
+						Attr("title", crud.t("Clear")).
+						Attr("v-on:click", "relationClear('"+fieldName+"')").
+						Text("×"),
+				}),
+				hb.Div().
+					Class("list-group position-absolute w-100 shadow-sm").
+					Style("z-index:1060;max-height:250px;overflow-y:auto;").
+					Attr("v-if", state+".open").
+					Children([]hb.TagInterface{
+						hb.Button().
+							Type(hb.TYPE_BUTTON).
+							Class("list-group-item list-group-item-action").
+							Attr("v-for", "option in "+state+".options").
+							Attr("v-bind:key", "option.key").
+							Attr("v-on:click", "relationSelect('"+fieldName+"', option)").
+							Text("{{ option.value }}"),
+						hb.Div().
+							Class("list-group-item text-muted").
+							Attr("v-if", state+".options.length === 0").
+							Text(crud.t("No matches found")),
+						hb.Button().
+							Type(hb.TYPE_BUTTON).
+							Class("list-group-item list-group-item-action text-primary").
+							Attr("v-if", state+".hasMore").
+							Attr("v-on:click", "relationSearch('"+fieldName+"', "+searchURL+", "+state+".page + 1)").
+							Text(crud.t("Load more...")),
+					}),
+			})
+		}
+
+		if field.isRepeater() {
+			formGroupInput = hb.Div().Child(crud.repeaterInput(field))
+		}
+
+		if field.Type == FORM_FIELD_TYPE_MARKDOWN {
+			formGroupInput = hb.Div().Child(crud.markdownEditor(field))
+		}
+
+		if field.Type == FORM_FIELD_TYPE_JSON {
+			formGroupInput = hb.Div().Child(crud.jsonEditor(field))
 		}
 
 		if field.Type == FORM_FIELD_TYPE_TEXTAREA {
-			formGroupInput = hb.TextArea().Class("form-control").Attr("v-model", "entityModel."+fieldName)
+			formGroupInput = hb.TextArea().Class(crud.theme.InputClass(field.Type)).Attr("v-model", "entityModel."+fieldName)
 		}
 
 		if field.Type == FORM_FIELD_TYPE_BLOCKAREA {
-			formGroupInput = hb.TextArea().Class("form-control").Attr("v-model", "entityModel."+fieldName)
+			formGroupInput = hb.TextArea().Class(crud.theme.InputClass(field.Type)).Attr("v-model", "entityModel."+fieldName)
 		}
 
 		if field.Type == FORM_FIELD_TYPE_RAW {
@@ -934,18 +1553,19 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 		}
 
 		formGroupInput.ID(fieldID)
-		if field.Type != FORM_FIELD_TYPE_RAW {
-			formGroup.AddChild(formGroupLabel)
+		formGroupInput = lockedInput(field, formGroupInput)
+		var label hb.TagInterface
+		if field.Type != FORM_FIELD_TYPE_RAW && !field.isBoolean() {
+			label = formGroupLabel
 		}
-		formGroup.AddChild(formGroupInput)
 
-		// Add help
-		if field.Help != "" {
-			formGroupHelp := hb.Paragraph().Class("text-info").HTML(field.Help)
-			formGroup.AddChild(formGroupHelp)
-		}
+		column := hb.Div().Class(fieldColumnClass(field)).Child(crud.theme.FormGroup(label, formGroupInput, field.Help))
 
-		tags = append(tags, formGroup)
+		if len(field.ShowIf) > 0 {
+			showIf, _ := utils.ToJSON(field.ShowIf)
+			column.Attr("v-show", "conditionsMatch("+showIf+")")
+		}
+		tags = append(tags, column)
 
 		if field.Type == FORM_FIELD_TYPE_BLOCKAREA {
 			script := hb.NewTag(`component`).
@@ -959,7 +1579,7 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 				blockArea.registerBlock(BlockAreaRawHtml);
 				blockArea.init();
 			}, 2000)`)
-			tags = append(tags, script)
+			column.AddChild(script)
 		}
 	}
 
@@ -967,20 +1587,19 @@ func (crud *Crud) form(fields []FormField) []hb.TagInterface {
 }
 
 // listCreateNames returns a list of names from the createFields
-// slice in the Crud struct.
+// slice in the Crud struct, which are editable for the request.
+// Hidden, read-only and disabled fields are left out, so that their
+// values cannot be posted.
 //
 // Parameters:
-//   - None
+//   - r: the HTTP request
 //
 // Returns:
 //   - []string - a list of field names
-func (crud *Crud) listCreateNames() []string {
+func (crud *Crud) listCreateNames(r *http.Request) []string {
 	names := []string{}
 
-	for _, field := range crud.createFields {
-		if field.Name == "" {
-			continue
-		}
+	for _, field := range editableFields(r, crud.createFields) {
 		names = append(names, field.Name)
 	}
 
@@ -988,22 +1607,34 @@ func (crud *Crud) listCreateNames() []string {
 }
 
 // listUpdateNames returns a list of names from the updateFields
-// slice in the Crud struct.
+// slice in the Crud struct, which are editable for the request.
+// Hidden, read-only and disabled fields are left out, so that their
+// values cannot be posted.
 //
 // Parameters:
-//   - None
+//   - r: the HTTP request
 //
 // Returns:
 //   - []string - a list of field names
-func (crud *Crud) listUpdateNames() []string {
+func (crud *Crud) listUpdateNames(r *http.Request) []string {
 	names := []string{}
 
-	for _, field := range crud.updateFields {
-		if field.Name == "" {
-			continue
-		}
+	for _, field := range editableFields(r, crud.updateFields) {
 		names = append(names, field.Name)
 	}
 
 	return names
 }
+
+// isRawMarked returns true if the text is wrapped in {!! !!},
+// meaning it should be rendered as raw HTML
+func isRawMarked(text string) bool {
+	return strings.HasPrefix(text, "{!!") && strings.HasSuffix(text, "!!}")
+}
+
+// stripRawMarkers removes the {!! !!} raw HTML markers from the text
+func stripRawMarkers(text string) string {
+	text = strings.ReplaceAll(text, "{!!", "")
+	text = strings.ReplaceAll(text, "!!}", "")
+	return strings.TrimSpace(text)
+}
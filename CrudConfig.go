@@ -7,21 +7,43 @@ import (
 )
 
 type CrudConfig struct {
+	Children            []*Crud
 	ColumnNames         []string
+	Columns             []Column
 	CreateFields        []FormField
 	Endpoint            string
 	EntityNamePlural    string
 	EntityNameSingular  string
+	FieldGroups         []FieldGroup
 	FileManagerURL      string
+	Filters             []Filter
 	FuncCreate          func(data map[string]string) (userID string, err error)
+	FuncCreateDefaults  func(r *http.Request) map[string]string
+	FuncDuplicate       func(entityID string, data map[string]string) map[string]string
+	FuncFetchLabels     func(entityIDs []string) (map[string]string, error)
 	FuncFetchReadData   func(entityID string) ([][2]string, error)
+	FuncFetchReadValues func(entityID string) (map[string]string, error)
 	FuncFetchUpdateData func(entityID string) (map[string]string, error)
+	FuncLocale          func(r *http.Request) string
 	FuncLayout          func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
 	FuncRows            func() (rows []Row, err error)
+	FuncRowsQuery       func(query RowsQuery) (rows []Row, facets map[string]map[string]int, err error)
+	FuncRowsByParent    func(parentID string) (rows []Row, err error)
+	FuncSearch          func(query string, page int) (options []FormFieldOption, hasMore bool, err error)
+	FuncTimezone        func(r *http.Request) string
 	FuncTrash           func(entityID string) error
 	FuncUpdate          func(entityID string, data map[string]string) error
+	FuncUpdateChanges   func(entityID string, changes map[string]string, before map[string]string, after map[string]string) error
+	FuncUserID          func(r *http.Request) string
 	HomeURL             string
+	Middlewares         []Middleware
+	ParentKey           string
+	PathRouting         bool
+	PreferencesStore    PreferencesStore
 	ReadFields          []FormField
+	Theme               Theme
+	Timezone            string
+	Translator          Translator
 	UpdateFields        []FormField
 	FuncReadExtras      func(entityID string) []hb.TagInterface
 }
@@ -1,7 +1,16 @@
 package crud
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/samber/lo"
 )
 
 func TestIErrorThrownWhenFuncRowsNotProvided(t *testing.T) {
@@ -22,3 +31,1253 @@ func TestIErrorThrownWhenFuncRowsNotProvided(t *testing.T) {
 	// 	t.Error("Crud endpoint MUST be TESTENDPOINT, found:", crud.endpoint)
 	// }
 }
+
+func TestErrorThrownWhenPreferencesStoreWithoutFuncUserID(t *testing.T) {
+	_, err := NewCrud(CrudConfig{
+		UpdateFields:     []FormField{},
+		PreferencesStore: NewMemoryPreferencesStore(),
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+	})
+
+	expected := "FuncUserID function is required by PreferencesStore"
+	if err == nil || err.Error() != expected {
+		t.Error("Error MUST be "+expected+" , but found: ", err)
+	}
+}
+
+func TestEntitySearchAjax(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/customers",
+		UpdateFields: []FormField{},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncSearch: func(query string, page int) ([]FormFieldOption, bool, error) {
+			if query != "jo" || page != 2 {
+				return nil, false, errors.New("unexpected query " + query)
+			}
+			return []FormFieldOption{{Key: "ID1", Value: "Jon Doe"}}, true, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", crud.UrlEntitySearchAjax()+"&q=jo&page=2", nil)
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	body := w.Body.String()
+
+	if !strings.Contains(body, `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", body)
+	}
+
+	if !strings.Contains(body, `{"key":"ID1","value":"Jon Doe"}`) {
+		t.Error("Response MUST contain the option, but found: ", body)
+	}
+
+	if !strings.Contains(body, `"has_more":true`) {
+		t.Error("Response MUST contain has_more, but found: ", body)
+	}
+}
+
+func TestRelationLinkWithReadFields(t *testing.T) {
+	customers, err := NewCrud(CrudConfig{
+		Endpoint:     "/customers",
+		UpdateFields: []FormField{},
+		ReadFields:   []FormField{{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name"}},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"name": "Jon Doe"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	link := relationLink(&customers, "ID1", "Jon Doe").ToHTML()
+
+	if !strings.Contains(link, `href="/customers?path=entity-read&amp;entity_id=ID1"`) {
+		t.Error("Relation MUST link to the read page, but found: ", link)
+	}
+}
+
+func TestEntityReadChildGrid(t *testing.T) {
+	products, err := NewCrud(CrudConfig{
+		Endpoint:     "/products",
+		UpdateFields: []FormField{},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncFetchReadData: func(entityID string) ([][2]string, error) {
+			return [][2]string{}, nil
+		},
+		FuncFetchLabels: func(entityIDs []string) (map[string]string, error) {
+			return map[string]string{"P1": "Shirt"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	translator := NewCatalogTranslator("en")
+	translator.AddMessages("de", map[string]string{"Quantity": "Menge"})
+
+	items, err := NewCrud(CrudConfig{
+		Endpoint:         "/order-items",
+		EntityNamePlural: "Items",
+		ParentKey:        "order_id",
+		Translator:       translator,
+		Columns: []Column{
+			{Name: "Product", Relation: &products},
+			{Name: "Quantity"},
+			{Name: "Internal", Hidden: true},
+		},
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{{Type: FORM_FIELD_TYPE_STRING, Name: "quantity", Label: "Quantity"}},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncRowsByParent: func(parentID string) ([]Row, error) {
+			return []Row{{ID: "I1", Data: []string{"P1", "2", "SECRET"}}}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			return "I2", nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	orders, err := NewCrud(CrudConfig{
+		Endpoint:     "/orders",
+		UpdateFields: []FormField{},
+		Children:     []*Crud{&items},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncFetchReadData: func(entityID string) ([][2]string, error) {
+			return [][2]string{{"Number", "O1"}}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", orders.UrlEntityReadByID("O1"), nil)
+	r.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	orders.Handler(w, r)
+	body := w.Body.String()
+
+	for _, expected := range []string{"<th>Menge</th>", `<a href="/products?path=entity-read&amp;entity_id=P1">Shirt</a>`} {
+		if !strings.Contains(body, expected) {
+			t.Error("Child grid MUST contain "+expected+", but found: ", body)
+		}
+	}
+
+	if strings.Contains(body, "Internal") || strings.Contains(body, "SECRET") {
+		t.Error("Child grid MUST NOT show the hidden column")
+	}
+
+	if strings.Contains(body, "modal-lg") {
+		t.Error("Child grid modals MUST NOT be large for a single column form")
+	}
+}
+
+func TestEntityCreateAjaxPassesParentKey(t *testing.T) {
+	created := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/order-items",
+		ParentKey:    "order_id",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "product", Label: "Product", Required: true},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			created = data
+			return "ITEM1", nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	form := url.Values{}
+	form.Add("product", "Book")
+	form.Add("order_id", "ORDER1")
+	r := httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	if created["order_id"] != "ORDER1" {
+		t.Error("Parent key MUST be passed to FuncCreate, but found: ", created)
+	}
+
+	if created["product"] != "Book" {
+		t.Error("Product MUST be Book, but found: ", created["product"])
+	}
+}
+
+func TestEntityReadUsesReadFields(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/users",
+		UpdateFields: []FormField{},
+		ReadFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name", Group: "Profile"},
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "status", Label: "Status", Group: "Profile", Options: []FormFieldOption{
+				{Key: "active", Value: "Active User"},
+			}},
+			{Type: FORM_FIELD_TYPE_PASSWORD, Name: "password", Label: "Password", Group: "Security"},
+			{Type: FORM_FIELD_TYPE_SWITCH, Name: "admin", Label: "Admin", Group: "Security"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncFetchReadValues: func(entityID string) (map[string]string, error) {
+			return map[string]string{
+				"name":     "Jon <b>Doe</b>",
+				"status":   "active",
+				"password": "secret",
+				"admin":    "1",
+			}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", crud.UrlEntityRead()+"&entity_id=ID1", nil)
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	body := w.Body.String()
+
+	expected := []string{
+		"Jon &lt;b&gt;Doe&lt;/b&gt;",
+		"Active User",
+		"••••••••",
+		">Yes<",
+		">Profile<",
+		">Security<",
+	}
+
+	for _, text := range expected {
+		if !strings.Contains(body, text) {
+			t.Error("Read page MUST contain " + text)
+		}
+	}
+
+	if strings.Contains(body, "secret") {
+		t.Error("Read page MUST NOT contain the password")
+	}
+}
+
+func TestEntityUpdateFormLayout(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint: "/users",
+		FieldGroups: []FieldGroup{
+			{Name: "profile", Label: "Profile", Type: FIELD_GROUP_TYPE_TAB},
+			{Name: "security", Label: "Security", Type: FIELD_GROUP_TYPE_TAB},
+			{Name: "notes", Label: "Notes", Type: FIELD_GROUP_TYPE_COLLAPSIBLE, Collapsed: true},
+		},
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "first_name", Label: "First Name", Group: "profile", Width: FORM_FIELD_WIDTH_HALF},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "last_name", Label: "Last Name", Group: "profile", Width: FORM_FIELD_WIDTH_HALF},
+			{Type: FORM_FIELD_TYPE_PASSWORD, Name: "password", Label: "Password", Group: "security"},
+			{Type: FORM_FIELD_TYPE_TEXTAREA, Name: "notes", Label: "Notes", Group: "notes"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", crud.UrlEntityUpdate()+"&entity_id=ID1", nil)
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	body := w.Body.String()
+
+	if strings.Count(body, `class="nav-link`) != 2 {
+		t.Error("Update page MUST contain 2 tabs")
+	}
+
+	if strings.Count(body, "col-12 col-md-6") != 2 {
+		t.Error("Update page MUST contain 2 half width columns")
+	}
+
+	if !strings.Contains(body, `data-bs-toggle="collapse"`) {
+		t.Error("Update page MUST contain a collapsible panel")
+	}
+}
+
+func TestEntityCreateAjaxConditionalRequired(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/customers",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "type", Label: "Type", Options: []FormFieldOption{
+				{Key: "person", Value: "Person"},
+				{Key: "business", Value: "Business"},
+			}},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "company_name", Label: "Company Name", Required: true, ShowIf: []FieldCondition{
+				{Field: "type", Value: "business"},
+			}},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "vat", Label: "VAT", RequiredIf: []FieldCondition{
+				{Field: "country", Operator: CONDITION_OPERATOR_IN, Values: []string{"DE", "FR"}},
+			}},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "country", Label: "Country"},
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "region", Label: "Region", DependsOn: []string{"country"}, DependentOptionsF: func(values map[string]string) []FormFieldOption {
+				return lo.Ternary(values["country"] == "DE", []FormFieldOption{{Key: "BY", Value: "Bavaria"}}, nil)
+			}},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			return "CUSTOMER1", nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	cases := []struct {
+		values   map[string]string
+		expected string
+	}{
+		{map[string]string{"type": "person"}, `"status":"success"`},
+		{map[string]string{"type": "business"}, "Company Name is required field"},
+		{map[string]string{"type": "business", "company_name": "ACME"}, `"status":"success"`},
+		{map[string]string{"type": "person", "country": "DE"}, "VAT is required field"},
+		{map[string]string{"type": "person", "country": "UK"}, `"status":"success"`},
+		{map[string]string{"type": "person", "country": "DE", "vat": "DE1", "region": "BY"}, `"status":"success"`},
+		{map[string]string{"type": "person", "country": "UK", "region": "BY"}, "Region has an option which is not available"},
+	}
+
+	for _, c := range cases {
+		form := url.Values{}
+		for key, value := range c.values {
+			form.Add(key, value)
+		}
+		r := httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+
+		if !strings.Contains(w.Body.String(), c.expected) {
+			t.Error("Response for ", c.values, " MUST contain ", c.expected, ", but found: ", w.Body.String())
+		}
+	}
+}
+
+func TestEntityCreateAjaxRepeater(t *testing.T) {
+	created := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/customers",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_REPEATER, Name: "addresses", Label: "Addresses", Required: true, Fields: []FormField{
+				{Type: FORM_FIELD_TYPE_STRING, Name: "street", Label: "Street"},
+				{Type: FORM_FIELD_TYPE_STRING, Name: "city", Label: "City", Required: true},
+				{Type: FORM_FIELD_TYPE_NUMBER, Name: "floor", Label: "Floor"},
+				{Type: FORM_FIELD_TYPE_CHECKBOX, Name: "primary", Label: "Primary"},
+			}},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			created = data
+			return "CUSTOMER1", nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	post := func(addresses string) string {
+		form := url.Values{}
+		form.Add("addresses", addresses)
+		r := httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		return w.Body.String()
+	}
+
+	if body := post(`[]`); !strings.Contains(body, "Addresses is required field") {
+		t.Error("Empty repeater MUST be required, but found: ", body)
+	}
+
+	if body := post(`[{"city":"London"},{"street":"Main St"}]`); !strings.Contains(body, "Addresses #2: City is required field") {
+		t.Error("Second item MUST fail the validation, but found: ", body)
+	}
+
+	body := post(`[{"city":"London","floor":3,"primary":true,"unknown":"x"}]`)
+	if !strings.Contains(body, `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", body)
+	}
+
+	expected := `[{"city":"London","floor":"3","primary":"1","street":""}]`
+	if created["addresses"] != expected {
+		t.Error("Addresses MUST be ", expected, ", but found: ", created["addresses"])
+	}
+}
+
+func TestEntityCreateAjaxComputed(t *testing.T) {
+	created := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/posts",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title", Required: true},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "slug", Label: "Slug", Required: true, ComputePreview: "crudSlug(values.title)", Compute: func(r *http.Request, values map[string]string) string {
+				return Slugify(lo.CoalesceOrEmpty(values["slug"], values["title"]))
+			}},
+			{Type: FORM_FIELD_TYPE_HIDDEN, Name: "created_by", Compute: func(r *http.Request, values map[string]string) string {
+				return r.Header.Get("X-User")
+			}},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			created = data
+			return "POST1", nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	post := func(form url.Values) string {
+		r := httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-User", "USER1")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		return w.Body.String()
+	}
+
+	body := post(url.Values{"title": {"Hello, Wörld!"}, "created_by": {"FORGED"}})
+	if !strings.Contains(body, `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", body)
+	}
+
+	if created["slug"] != "hello-wörld" {
+		t.Error("Slug MUST be hello-wörld, but found: ", created["slug"])
+	}
+
+	if created["created_by"] != "USER1" {
+		t.Error("Created by MUST be USER1, but found: ", created["created_by"])
+	}
+
+	post(url.Values{"title": {"Hello"}, "slug": {"My Custom Slug"}})
+	if created["slug"] != "my-custom-slug" {
+		t.Error("Slug MUST be my-custom-slug, but found: ", created["slug"])
+	}
+}
+
+func TestEntityUpdateAjaxComputed(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/London"); err != nil {
+		t.Skip("Timezone data not available: ", err.Error())
+	}
+
+	updated := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint: "/posts",
+		Timezone: "Europe/London",
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "code", Label: "Code", Readonly: true},
+			{Type: FORM_FIELD_TYPE_DATETIME, Name: "published_at", Label: "Published At"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "reason", Label: "Reason", RequiredIf: []FieldCondition{
+				{Field: "code", Value: "P1"},
+			}},
+			{Type: FORM_FIELD_TYPE_HIDDEN, Name: "summary", Compute: func(r *http.Request, values map[string]string) string {
+				return values["code"] + "@" + values["published_at"]
+			}},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			updated = data
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"title": "Hello", "code": "P1", "published_at": "2024-07-01 09:30:00"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	post := func(form url.Values) string {
+		r := httptest.NewRequest("POST", crud.UrlEntityUpdateAjax(), strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		return w.Body.String()
+	}
+
+	if body := post(url.Values{"entity_id": {"P1"}, "title": {"Hi"}}); !strings.Contains(body, "Reason is required") {
+		t.Error("Reason MUST be required by the read-only code, but found: ", body)
+	}
+
+	if body := post(url.Values{"entity_id": {"P1"}, "title": {"Hi"}, "reason": {"Typo"}}); !strings.Contains(body, `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", body)
+	}
+
+	if updated["summary"] != "P1@2024-07-01 10:30:00" || updated["published_at"] != "2024-07-01 09:30:00" {
+		t.Error("Summary MUST be computed from the values as shown, but found: ", updated)
+	}
+
+	if _, exists := updated["code"]; exists {
+		t.Error("Code MUST NOT be updated, but found: ", updated)
+	}
+
+	post(url.Values{"entity_id": {"P1"}, "reason": {"Typo"}, "published_at": {"2024-07-01 12:00:00"}})
+
+	if updated["summary"] != "P1@2024-07-01 12:00:00" || updated["published_at"] != "2024-07-01 11:00:00" {
+		t.Error("Published at MUST be stored in UTC, but found: ", updated)
+	}
+}
+
+func TestEntityUpdateAjaxStripsLockedFields(t *testing.T) {
+	updated := map[string]string{}
+	isAdmin := func(r *http.Request) bool {
+		return r.Header.Get("X-Role") == "admin"
+	}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint: "/employees",
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "code", Label: "Code", Readonly: true},
+			{Type: FORM_FIELD_TYPE_NUMBER, Name: "salary", Label: "Salary", VisibleF: isAdmin},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "team", Label: "Team", EditableF: isAdmin},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			updated = data
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"name": "Jane", "code": "E1", "salary": "5000", "team": "Sales"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	form := url.Values{"entity_id": {"E1"}, "name": {"John"}, "code": {"X"}, "salary": {"9999"}, "team": {"IT"}}
+	r := httptest.NewRequest("POST", crud.UrlEntityUpdateAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	if len(updated) != 1 || updated["name"] != "John" {
+		t.Error("Only the name MUST be updated, but found: ", updated)
+	}
+
+	r = httptest.NewRequest("GET", crud.UrlEntityUpdate()+"&entity_id=E1", nil)
+	w = httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if strings.Contains(w.Body.String(), "5000") {
+		t.Error("Salary MUST NOT be sent to the browser")
+	}
+
+	teamInput := regexp.MustCompile(`<input[^>]*v-model="entityModel.team"[^>]*>`)
+
+	if input := teamInput.FindString(w.Body.String()); !strings.Contains(input, `readonly="readonly"`) {
+		t.Error("Team MUST be read-only, but found: ", input)
+	}
+
+	r = httptest.NewRequest("GET", crud.UrlEntityUpdate()+"&entity_id=E1", nil)
+	r.Header.Set("X-Role", "admin")
+	w = httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if input := teamInput.FindString(w.Body.String()); input == "" || strings.Contains(input, `readonly="readonly"`) {
+		t.Error("Team MUST be editable by the admins, but found: ", input)
+	}
+}
+
+func TestEntityManagerCreateDefaults(t *testing.T) {
+	created := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/orders",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "owner", Label: "Owner", Readonly: true},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "customer_id", Label: "Customer"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "status", Label: "Status", Value: "new"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			created = data
+			return "ORDER1", nil
+		},
+		FuncCreateDefaults: func(r *http.Request) map[string]string {
+			return map[string]string{"owner": r.Header.Get("X-User")}
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	createURL := crud.UrlEntityCreate(map[string]string{"customer_id": "C 1", "owner": "FORGED"})
+	if createURL != "/orders?path=entity-manager&create=1&customer_id=C+1&owner=FORGED" {
+		t.Fatal("Create URL is not correct: ", createURL)
+	}
+
+	r := httptest.NewRequest("GET", createURL, nil)
+	r.Header.Set("X-User", "USER1")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+	body := w.Body.String()
+
+	for _, expected := range []string{`"owner":"USER1"`, `"customer_id":"C 1"`, `"status":"new"`, `const openCreate = true;`} {
+		if !strings.Contains(body, expected) {
+			t.Error("Page MUST contain ", expected)
+		}
+	}
+
+	// the read-only owner is saved from the defaults, not from the post
+	form := url.Values{"customer_id": {"C1"}, "owner": {"FORGED"}}
+	r = httptest.NewRequest("POST", crud.UrlEntityCreateAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-User", "USER1")
+	w = httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	if created["owner"] != "USER1" || created["customer_id"] != "C1" {
+		t.Error("Owner MUST be the default USER1, but found: ", created)
+	}
+}
+
+func TestEntityManagerDuplicate(t *testing.T) {
+	created := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/products",
+		UpdateFields: []FormField{},
+		CreateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "sku", Label: "SKU"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncCreate: func(data map[string]string) (string, error) {
+			created = data
+			return "PRODUCT2", nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			if entityID != "PRODUCT1" {
+				return nil, errors.New("not found")
+			}
+			return map[string]string{"title": "Shirt", "sku": "SHIRT-1", "secret": "x"}, nil
+		},
+		FuncDuplicate: func(entityID string, data map[string]string) map[string]string {
+			data["title"] = data["title"] + " (copy)"
+			data["sku"] = ""
+			return data
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", crud.UrlEntityDuplicate("PRODUCT1"), nil)
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+	body := w.Body.String()
+
+	for _, expected := range []string{`"title":"Shirt (copy)"`, `"sku":""`, `const openCreate = true;`} {
+		if !strings.Contains(body, expected) {
+			t.Error("Page MUST contain ", expected)
+		}
+	}
+
+	if strings.Contains(body, `"secret"`) {
+		t.Error("Page MUST NOT contain the values of other fields")
+	}
+
+	createURL := crud.UrlEntityCreateAjax() + "&duplicate=PRODUCT1"
+	if !strings.Contains(body, `const entityCreateUrl = "`+strings.ReplaceAll(createURL, "&", `\u0026`)+`";`) {
+		t.Error("Duplicate MUST be created with the URL ", createURL)
+	}
+
+	// the values of the entity posted back are changed by FuncDuplicate
+	cases := []struct {
+		posted   url.Values
+		expected map[string]string
+	}{
+		{url.Values{"title": {"Shirt"}, "sku": {"SHIRT-1"}}, map[string]string{"title": "Shirt (copy)", "sku": ""}},
+		{url.Values{"title": {"Blouse"}, "sku": {"BLOUSE-1"}}, map[string]string{"title": "Blouse", "sku": "BLOUSE-1"}},
+	}
+
+	for _, c := range cases {
+		r = httptest.NewRequest("POST", createURL, strings.NewReader(c.posted.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		crud.Handler(httptest.NewRecorder(), r)
+
+		if created["title"] != c.expected["title"] || created["sku"] != c.expected["sku"] {
+			t.Error("Created values MUST be ", c.expected, ", but found: ", created)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityDuplicate("MISSING"), nil))
+	body = w.Body.String()
+
+	if !strings.Contains(body, "The entity to duplicate could not be fetched") || !strings.Contains(body, `const openCreate = false;`) {
+		t.Error("Page MUST show the error of fetching the entity to duplicate")
+	}
+}
+
+func TestEntityInlineUpdateAjax(t *testing.T) {
+	updated := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint: "/products",
+		Columns: []Column{
+			{Name: "Title"},
+			{Name: "Status", Field: "status"},
+		},
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "price", Label: "Price"},
+			{Type: FORM_FIELD_TYPE_SELECT, Name: "status", Label: "Status", Required: true, Options: []FormFieldOption{
+				{Key: "draft", Value: "Draft"},
+				{Key: "active", Value: "Active"},
+			}},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{{ID: "P1", Data: []string{"Shirt", "draft"}}}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			updated = data
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"title": "Shirt", "price": "10", "status": "draft"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	post := func(form url.Values) string {
+		r := httptest.NewRequest("POST", crud.UrlEntityInlineUpdateAjax(), strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		return w.Body.String()
+	}
+
+	if body := post(url.Values{"entity_id": {"P1"}, "field": {"title"}, "title": {"X"}}); !strings.Contains(body, "cannot be edited inline") {
+		t.Error("Title MUST NOT be editable inline, but found: ", body)
+	}
+
+	if body := post(url.Values{"entity_id": {"P1"}, "field": {"status"}, "status": {""}}); !strings.Contains(body, "Status is required field") {
+		t.Error("Status MUST be required, but found: ", body)
+	}
+
+	body := post(url.Values{"entity_id": {"P1"}, "field": {"status"}, "status": {"active"}})
+	if !strings.Contains(body, `"display":"Active"`) {
+		t.Fatal("Response MUST contain the label of the option, but found: ", body)
+	}
+
+	if updated["status"] != "active" || updated["title"] != "Shirt" || updated["price"] != "10" {
+		t.Error("Only the status MUST change, but found: ", updated)
+	}
+}
+
+func TestEntityUpdateAjaxChanges(t *testing.T) {
+	changes := map[string]string{}
+	after := map[string]string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint: "/products",
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "title", Label: "Title"},
+			{Type: FORM_FIELD_TYPE_STRING, Name: "description", Label: "Description"},
+			{Type: FORM_FIELD_TYPE_NUMBER, Name: "price", Label: "Price"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncUpdateChanges: func(entityID string, changed map[string]string, before map[string]string, updated map[string]string) error {
+			changes = changed
+			after = updated
+			return nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"id": "P1", "title": "Shirt", "description": "Cotton", "price": "10"}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	form := url.Values{"entity_id": {"P1"}, "title": {"Shirt"}, "description": {""}}
+	r := httptest.NewRequest("POST", crud.UrlEntityUpdateAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	if len(changes) != 1 || changes["description"] != "" {
+		t.Error("Only the description MUST be changed, but found: ", changes)
+	}
+
+	if after["price"] != "10" || after["id"] != "P1" || after["description"] != "" {
+		t.Error("The absent fields MUST be kept, but found: ", after)
+	}
+}
+
+func TestEntityManagerFilters(t *testing.T) {
+	query := RowsQuery{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/tickets?section=support",
+		UpdateFields: []FormField{},
+		Filters: []Filter{
+			{Name: "title", Label: "Title"},
+			{Name: "status", Label: "Status", Type: FILTER_TYPE_SELECT, Options: []FormFieldOption{
+				{Key: "open", Value: "Open"},
+				{Key: "closed", Value: "Closed"},
+			}},
+			{Name: "urgent", Label: "Urgent", Type: FILTER_TYPE_BOOLEAN},
+			{Name: "created", Label: "Created", Type: FILTER_TYPE_DATE_RANGE},
+			{Name: "price", Label: "Price", Type: FILTER_TYPE_NUMBER_RANGE},
+		},
+		FuncRowsQuery: func(rowsQuery RowsQuery) ([]Row, map[string]map[string]int, error) {
+			query = rowsQuery
+			return []Row{}, map[string]map[string]int{"status": {"open": 12, "closed": 3}}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	params := "&filter_title=+printer+&filter_status=open&filter_urgent=maybe&filter_created_from=2024-01-01&filter_created_to=bad&filter_price_to=99.5"
+	r := httptest.NewRequest("GET", crud.UrlEntityManager()+params, nil)
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+	body := w.Body.String()
+
+	expected := []FilterValue{
+		{Name: "title", Type: FILTER_TYPE_TEXT, Value: "printer"},
+		{Name: "status", Type: FILTER_TYPE_SELECT, Value: "open"},
+		{Name: "created", Type: FILTER_TYPE_DATE_RANGE, From: "2024-01-01"},
+		{Name: "price", Type: FILTER_TYPE_NUMBER_RANGE, To: "99.5"},
+	}
+
+	if len(query.Filters) != len(expected) {
+		t.Fatal("Filters MUST be ", expected, ", but found: ", query.Filters)
+	}
+
+	for index, filter := range expected {
+		if query.Filters[index] != filter {
+			t.Error("Filter MUST be ", filter, ", but found: ", query.Filters[index])
+		}
+	}
+
+	for _, html := range []string{`<option selected="selected" value="open">Open (12)</option>`, `name="section" type="hidden" value="support"`, `action="/tickets"`} {
+		if !strings.Contains(body, html) {
+			t.Error("Page MUST contain ", html)
+		}
+	}
+}
+
+func TestEntityManagerViews(t *testing.T) {
+	query := RowsQuery{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:         "/tickets",
+		EntityNamePlural: "Tickets",
+		Columns:          []Column{{Name: "Title"}, {Name: "Status"}, {Name: "{!!Owner!!}"}},
+		UpdateFields:     []FormField{},
+		Filters: []Filter{
+			{Name: "status", Label: "Status", Type: FILTER_TYPE_SELECT},
+		},
+		PreferencesStore: NewMemoryPreferencesStore(),
+		FuncUserID: func(r *http.Request) string {
+			return r.Header.Get("X-User")
+		},
+		FuncRowsQuery: func(rowsQuery RowsQuery) ([]Row, map[string]map[string]int, error) {
+			query = rowsQuery
+			return []Row{{ID: "1", Data: []string{"Printer", "open", "<b>Ann</b>"}}}, nil, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	form := url.Values{
+		"name":    {"My open tickets"},
+		"default": {"1"},
+		"query":   {"filter_status=open&sort=Owner&sort_dir=desc&columns=Owner&columns=Title&columns=Unknown"},
+	}
+	r := httptest.NewRequest("POST", crud.UrlEntityViewSaveAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-User", "ann")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	// the default view applies to its user only
+	for user, views := range map[string]int{"ann": 1, "bob": 0} {
+		r = httptest.NewRequest("GET", crud.UrlEntityManager(), nil)
+		r.Header.Set("X-User", user)
+		w = httptest.NewRecorder()
+		crud.Handler(w, r)
+		body := w.Body.String()
+
+		if len(crud.views(r)) != views {
+			t.Error("Views of ", user, " MUST be ", views, ", but found: ", len(crud.views(r)))
+		}
+
+		if views == 0 {
+			if len(query.Filters) != 0 || query.Sort != "" || strings.Join(query.Columns, ",") != "Title,Status,Owner" {
+				t.Error("Query MUST be empty, but found: ", query)
+			}
+			continue
+		}
+
+		if len(query.Filters) != 1 || query.Filters[0].Value != "open" {
+			t.Error("Filters MUST be status open, but found: ", query.Filters)
+		}
+
+		if query.Sort != "Owner" || !query.SortDescending {
+			t.Error("Sort MUST be Owner descending, but found: ", query.Sort, query.SortDescending)
+		}
+
+		if strings.Join(query.Columns, ",") != "Owner,Title" {
+			t.Error("Columns MUST be Owner,Title, but found: ", query.Columns)
+		}
+
+		if !strings.Contains(body, `<th>Owner</th><th>Title</th><td`) {
+			t.Error("Table MUST show the columns of the view")
+		}
+
+		if !strings.Contains(body, `const tableOrder = [[0,"desc"]];`) {
+			t.Error("Table MUST be sorted by the view")
+		}
+	}
+
+	// the query of the URL takes precedence over the default view
+	r = httptest.NewRequest("GET", crud.UrlEntityManager()+"&filter_status=closed", nil)
+	r.Header.Set("X-User", "ann")
+	w = httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if len(query.Filters) != 1 || query.Filters[0].Value != "closed" || len(query.Columns) != 3 {
+		t.Error("Query MUST be of the URL, but found: ", query)
+	}
+}
+
+func TestEntityManagerColumns(t *testing.T) {
+	query := RowsQuery{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:         "/tickets",
+		EntityNamePlural: "Tickets",
+		Columns:          []Column{{Name: "Title"}, {Name: "Status"}, {Name: "Notes", Hidden: true}},
+		UpdateFields:     []FormField{},
+		PreferencesStore: NewMemoryPreferencesStore(),
+		FuncUserID: func(r *http.Request) string {
+			return "USER1"
+		},
+		FuncRowsQuery: func(rowsQuery RowsQuery) ([]Row, map[string]map[string]int, error) {
+			query = rowsQuery
+			return []Row{{ID: "1", Data: []string{"Printer", "open", "Out of toner"}}}, nil, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	manager := func() string {
+		w := httptest.NewRecorder()
+		crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityManager(), nil))
+		return w.Body.String()
+	}
+
+	body := manager()
+
+	if strings.Join(query.Columns, ",") != "Title,Status" || strings.Contains(body, "Out of toner") {
+		t.Error("Hidden columns MUST NOT be shown, but found: ", query.Columns)
+	}
+
+	form := url.Values{"columns[]": {"Notes", "Title", "Unknown"}}
+	r := httptest.NewRequest("POST", crud.UrlEntityColumnsSaveAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	if !strings.Contains(w.Body.String(), `"status":"success"`) {
+		t.Fatal("Response MUST be success, but found: ", w.Body.String())
+	}
+
+	body = manager()
+
+	if strings.Join(query.Columns, ",") != "Notes,Title" || !strings.Contains(body, "<th>Notes</th><th>Title</th><td") {
+		t.Error("Columns MUST be the ones of the user, but found: ", query.Columns)
+	}
+
+	expected := `const columnsList = [{"label":"Notes","name":"Notes","visible":true},{"label":"Title","name":"Title","visible":true},{"label":"Status","name":"Status","visible":false}];`
+	if !strings.Contains(body, expected) {
+		t.Error("Columns dropdown MUST list ", expected)
+	}
+
+	// no columns reset the columns to the default
+	r = httptest.NewRequest("POST", crud.UrlEntityColumnsSaveAjax(), strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	crud.Handler(httptest.NewRecorder(), r)
+	manager()
+
+	if strings.Join(query.Columns, ",") != "Title,Status" {
+		t.Error("Columns MUST be the default, but found: ", query.Columns)
+	}
+}
+
+func TestPathRouting(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:           "/users",
+		EntityNameSingular: "User",
+		PathRouting:        true,
+		ColumnNames:        []string{"Name"},
+		ReadFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name"},
+		},
+		UpdateFields: []FormField{
+			{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name"},
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{{ID: "ID 1", Data: []string{"Jon"}}}, nil
+		},
+		FuncFetchReadValues: func(entityID string) (map[string]string, error) {
+			return map[string]string{"name": "Read " + entityID}, nil
+		},
+		FuncFetchUpdateData: func(entityID string) (map[string]string, error) {
+			return map[string]string{"name": "Update " + entityID}, nil
+		},
+		FuncUpdate: func(entityID string, data map[string]string) error {
+			return nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/users/", http.StripPrefix("/users", crud))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		return w
+	}
+
+	body := get("/users/").Body.String()
+
+	for _, html := range []string{"User Manager", `href="/users/ID%201"`, `href="/users/ID%201/edit"`} {
+		if !strings.Contains(body, html) {
+			t.Error("Entity manager MUST contain ", html)
+		}
+	}
+
+	if body := get("/users/ID%201").Body.String(); !strings.Contains(body, "Read ID 1") {
+		t.Error("Read page MUST be routed by the path")
+	}
+
+	if body := get("/users/ID%201/edit").Body.String(); !strings.Contains(body, "Update ID 1") {
+		t.Error("Update page MUST be routed by the path")
+	}
+
+	// the query routing keeps working
+	if body := get("/users/?path=entity-read&entity_id=ID2").Body.String(); !strings.Contains(body, "Read ID2") {
+		t.Error("Read page MUST be routed by the query")
+	}
+
+	if code := get("/users/ID1/edit/more").Code; code != http.StatusNotFound {
+		t.Error("Status MUST be 404, but found: ", code)
+	}
+}
+
+func TestEntityManagerFormatsAndExport(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:         "/orders",
+		EntityNamePlural: "Orders",
+		Columns: []Column{
+			{Name: "Customer"},
+			{Name: "Total", Format: FORMAT_CURRENCY, Currency: "EUR"},
+			{Name: "Discount", Format: FORMAT_PERCENT},
+		},
+		UpdateFields: []FormField{},
+		FuncLocale: func(r *http.Request) string {
+			return "de"
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{{ID: "1", Data: []string{"Müller, Anna", "1234.5", "0.15"}}}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	w := httptest.NewRecorder()
+	crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityManager(), nil))
+	body := w.Body.String()
+
+	for _, html := range []string{"<td data-order=\"1234.5\">1.234,50\u00a0€</td>", "15\u00a0%", crud.UrlEntityExport()} {
+		if !strings.Contains(body, html) {
+			t.Error("Entity manager MUST contain ", html)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityExport()+"&columns=Customer&columns=Total", nil))
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/csv; charset=utf-8" {
+		t.Error("Content type MUST be CSV, but found: ", contentType)
+	}
+
+	if disposition := w.Header().Get("Content-Disposition"); disposition != `attachment; filename="Orders.csv"` {
+		t.Error("Export MUST be an attachment, but found: ", disposition)
+	}
+
+	expected := "Customer,Total\n\"Müller, Anna\",1234.5\n"
+	if w.Body.String() != expected {
+		t.Error("Export MUST hold the raw values "+expected+", but found: ", w.Body.String())
+	}
+}
+
+func TestEntityExportEscapesFormulas(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:         "/orders",
+		EntityNamePlural: "Orders",
+		Columns:          []Column{{Name: "Customer"}, {Name: "Note"}},
+		UpdateFields:     []FormField{},
+		FuncRows: func() ([]Row, error) {
+			return []Row{
+				{ID: "1", Data: []string{"=SUM(A1:A2)", "+1"}},
+				{ID: "2", Data: []string{"@cmd", "-5"}},
+				{ID: "4", Data: []string{"+4930123", "-2+3"}},
+				{ID: "3", Data: []string{"\t=1+1", "Plain = text"}},
+			}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	w := httptest.NewRecorder()
+	crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityExport(), nil))
+
+	expected := "Customer,Note\n'=SUM(A1:A2),+1\n'@cmd,-5\n+4930123,'-2+3\n'=1+1,Plain = text\n"
+	if w.Body.String() != expected {
+		t.Error("Export MUST prefix the formulas with a quote "+expected+", but found: ", w.Body.String())
+	}
+}
+
+func TestEntityMarkdownPreviewAjax(t *testing.T) {
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/posts",
+		UpdateFields: []FormField{},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	form := url.Values{"markdown": {"# Title"}}
+	r := httptest.NewRequest("POST", crud.UrlEntityMarkdownPreviewAjax(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	crud.Handler(w, r)
+
+	for _, expected := range []string{`"status":"success"`, `"html":"\u003ch1\u003eTitle\u003c/h1\u003e\n"`} {
+		if !strings.Contains(w.Body.String(), expected) {
+			t.Error("Response MUST contain ", expected, ", but found: ", w.Body.String())
+		}
+	}
+}
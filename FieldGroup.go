@@ -0,0 +1,16 @@
+package crud
+
+// FieldGroup defines how a group of fields is laid out on the forms
+// and on the read page. The fields belong to the group with the same
+// Name as their Group.
+//
+// Type is one of FIELD_GROUP_TYPE_SECTION (the default),
+// FIELD_GROUP_TYPE_TAB or FIELD_GROUP_TYPE_COLLAPSIBLE. Consecutive
+// tab groups are shown together as one set of tabs.
+type FieldGroup struct {
+	Name      string
+	Label     string
+	Help      string
+	Type      string
+	Collapsed bool
+}
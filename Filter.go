@@ -0,0 +1,27 @@
+package crud
+
+// Filter defines a filter of the filter bar above the entity manager
+// table. The active filters are kept in the URL, so that the filtered
+// list can be shared, and passed to FuncRowsQuery.
+//
+// Example:
+//
+//	crud.Filter{Name: "status", Label: "Status", Type: crud.FILTER_TYPE_SELECT, Options: statuses}
+type Filter struct {
+	// Name is the name of the filter, used in the URL and in the query
+	Name string
+
+	// Label is shown above the input of the filter, defaults to the Name
+	Label string
+
+	// Type is one of the FILTER_TYPE_* constants,
+	// defaults to FILTER_TYPE_TEXT
+	Type string
+
+	// Options are the options of the select filters
+	Options []FormFieldOption
+
+	// OptionsF returns the options of the select filters,
+	// added after Options
+	OptionsF func() []FormFieldOption
+}
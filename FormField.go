@@ -1,5 +1,7 @@
 package crud
 
+import "net/http"
+
 type FormField struct {
 	ID       string
 	Type     string
@@ -10,4 +12,147 @@ type FormField struct {
 	Options  []FormFieldOption
 	OptionsF func() []FormFieldOption
 	Required bool
+
+	// Group is the name of the group of the field. Consecutive fields
+	// with the same group are shown together under the group name
+	Group string
+
+	// Width is the width of the field in the grid of the form, one of
+	// FORM_FIELD_WIDTH_FULL (the default), FORM_FIELD_WIDTH_HALF,
+	// FORM_FIELD_WIDTH_THIRD or FORM_FIELD_WIDTH_TWO_THIRDS
+	Width string
+
+	// Relation is the Crud of the related entity, used by the relation
+	// fields to search the entities and to show their labels
+	Relation *Crud
+
+	// ShowIf are the conditions, all of which must match, for the field
+	// to be shown. Hidden fields are not required
+	ShowIf []FieldCondition
+
+	// RequiredIf are the conditions, all of which must match, for the
+	// field to be required, in addition to the Required flag
+	RequiredIf []FieldCondition
+
+	// DependsOn are the names of the fields the options of the field
+	// depend on. The options are reloaded when any of them changes
+	DependsOn []string
+
+	// DependentOptionsF returns the options of the field for the current
+	// values of the form, added after Options and OptionsF
+	DependentOptionsF func(values map[string]string) []FormFieldOption
+
+	// Fields are the nested fields of each item of a repeater field
+	Fields []FormField
+
+	// JSONSchema is the JSON Schema the value of a JSON field is
+	// validated against on save, optional
+	JSONSchema string
+
+	// DateFormat is the format the values of the date, time, datetime
+	// and daterange fields are stored in, one of the DATE_FORMAT_*
+	// constants or a Go time layout. Defaults to DATE_FORMAT_DATE for
+	// dates and date ranges, DATE_FORMAT_TIME for times and
+	// DATE_FORMAT_DATETIME for dates with a time, stored in UTC
+	DateFormat string
+
+	// Format formats the value of the field on the read page, in the
+	// locale and the timezone of the user, one of the FORMAT_* constants.
+	// The date fields are shown with FORMAT_DATE or FORMAT_DATETIME by
+	// default
+	Format string
+
+	// Currency is the ISO 4217 code of the currency of the value of
+	// a FORMAT_CURRENCY field, e.g. "EUR"
+	Currency string
+
+	// Compute returns the value of the field from the other posted
+	// values and the request, e.g. a slug from the title or the ID of
+	// the current user. It runs before the validation, and the result
+	// replaces the posted value before FuncCreate and FuncUpdate
+	Compute func(r *http.Request, values map[string]string) string
+
+	// ComputePreview is a JavaScript expression over the values of the
+	// form, e.g. "crudSlug(values.title)", previewing the computed value
+	// while the form is edited. The field follows the expression until
+	// the user changes its value by hand
+	ComputePreview string
+
+	// Readonly shows the value of the field without allowing to edit it
+	Readonly bool
+
+	// Disabled shows the field greyed out, without allowing to edit it
+	Disabled bool
+
+	// VisibleF returns if the field is shown for the request, e.g. only
+	// to some roles. Hidden fields are not sent to the browser
+	VisibleF func(r *http.Request) bool
+
+	// EditableF returns if the field can be edited for the request,
+	// the field is shown as read-only otherwise
+	EditableF func(r *http.Request) bool
+}
+
+// isMultiValued returns true if the field holds a list of values,
+// which are encoded as a JSON array in the data maps
+func (field FormField) isMultiValued() bool {
+	return field.Type == FORM_FIELD_TYPE_MULTISELECT ||
+		field.Type == FORM_FIELD_TYPE_CHECKBOXES ||
+		field.Type == FORM_FIELD_TYPE_TAGS
+}
+
+// isBoolean returns true if the field holds a single on/off value,
+// which is stored as "1" or "0"
+func (field FormField) isBoolean() bool {
+	return field.Type == FORM_FIELD_TYPE_CHECKBOX ||
+		field.Type == FORM_FIELD_TYPE_SWITCH
+}
+
+// options returns the static options followed by the ones
+// returned by OptionsF, if set
+func (field FormField) options() []FormFieldOption {
+	options := append([]FormFieldOption{}, field.Options...)
+
+	if field.OptionsF != nil {
+		options = append(options, field.OptionsF()...)
+	}
+
+	return options
+}
+
+// isVisible returns true if the field is shown for the values of the form
+func (field FormField) isVisible(values map[string]string) bool {
+	return conditionsMatch(field.ShowIf, values)
+}
+
+// isRequired returns true if the field must be filled in for the
+// values of the form. Hidden fields are never required
+func (field FormField) isRequired(values map[string]string) bool {
+	if !field.isVisible(values) {
+		return false
+	}
+
+	if field.Required {
+		return true
+	}
+
+	return len(field.RequiredIf) > 0 && conditionsMatch(field.RequiredIf, values)
+}
+
+// isRepeater returns true if the field holds a list of items with
+// nested fields, which are encoded as a JSON array of objects
+func (field FormField) isRepeater() bool {
+	return field.Type == FORM_FIELD_TYPE_REPEATER
+}
+
+// isComputed returns true if the value of the field is computed
+// on the server from the other values
+func (field FormField) isComputed() bool {
+	return field.Compute != nil && field.Name != ""
+}
+
+// hasDependentOptions returns true if the options of the field
+// depend on the values of other fields
+func (field FormField) hasDependentOptions() bool {
+	return field.DependentOptionsF != nil
 }
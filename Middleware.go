@@ -0,0 +1,66 @@
+package crud
+
+import (
+	"net/http"
+
+	"github.com/samber/lo"
+)
+
+// Middleware runs around the handlers of the routes of the Crud, e.g.
+// for logging, authorization, rate limiting or panic recovery.
+//
+// Example:
+//
+//	crud.Middleware{
+//		Routes: []string{crud.ROUTE_ENTITY_UPDATE_AJAX, crud.ROUTE_ENTITY_TRASH_AJAX},
+//		Handler: func(w http.ResponseWriter, r *http.Request, action crud.Action, next http.HandlerFunc) {
+//			if !auth.CanEdit(r, action.EntityID) {
+//				http.Error(w, "Forbidden", http.StatusForbidden)
+//				return
+//			}
+//			next(w, r)
+//		},
+//	}
+type Middleware struct {
+	// Routes are the ROUTE_* constants of the routes the middleware
+	// runs around, all the routes if empty
+	Routes []string
+
+	// Handler runs around the handler of the route, calling next to
+	// continue, or else responding itself
+	Handler func(w http.ResponseWriter, r *http.Request, action Action, next http.HandlerFunc)
+}
+
+// Action is the route resolved for a request, passed to the middlewares
+type Action struct {
+	// Route is the route of the request, one of the ROUTE_* constants
+	Route string
+
+	// EntityID is the ID of the entity of the request,
+	// empty if the route has none
+	EntityID string
+}
+
+// appliesTo returns true if the middleware runs around the route
+func (middleware Middleware) appliesTo(route string) bool {
+	return len(middleware.Routes) == 0 || lo.Contains(middleware.Routes, route)
+}
+
+// chain wraps the handler in the middlewares which apply to the route
+// of the action, the first middleware running first
+func chain(middlewares []Middleware, action Action, handler http.HandlerFunc) http.HandlerFunc {
+	for index := len(middlewares) - 1; index >= 0; index-- {
+		middleware := middlewares[index]
+
+		if !middleware.appliesTo(action.Route) {
+			continue
+		}
+
+		next := handler
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			middleware.Handler(w, r, action, next)
+		}
+	}
+
+	return handler
+}
@@ -0,0 +1,81 @@
+package crud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewares(t *testing.T) {
+	actions := []Action{}
+	trashed := []string{}
+
+	crud, err := NewCrud(CrudConfig{
+		Endpoint:     "/users",
+		UpdateFields: []FormField{},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncTrash: func(entityID string) error {
+			trashed = append(trashed, entityID)
+			return nil
+		},
+		Middlewares: []Middleware{
+			{
+				Handler: func(w http.ResponseWriter, r *http.Request, action Action, next http.HandlerFunc) {
+					actions = append(actions, action)
+					next(w, r)
+				},
+			},
+			{
+				Routes: []string{ROUTE_ENTITY_TRASH_AJAX},
+				Handler: func(w http.ResponseWriter, r *http.Request, action Action, next http.HandlerFunc) {
+					if action.EntityID == "protected" {
+						http.Error(w, "Forbidden", http.StatusForbidden)
+						return
+					}
+					next(w, r)
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	requests := []*http.Request{
+		httptest.NewRequest("GET", "/users", nil),
+		httptest.NewRequest("POST", crud.UrlEntityTrashAjax(), strings.NewReader("entity_id=protected")),
+		httptest.NewRequest("POST", crud.UrlEntityTrashAjax(), strings.NewReader("entity_id=E1")),
+	}
+
+	codes := []int{}
+	for _, r := range requests {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		crud.Handler(w, r)
+		codes = append(codes, w.Code)
+	}
+
+	expected := []Action{
+		{Route: ROUTE_ENTITY_MANAGER},
+		{Route: ROUTE_ENTITY_TRASH_AJAX, EntityID: "protected"},
+		{Route: ROUTE_ENTITY_TRASH_AJAX, EntityID: "E1"},
+	}
+
+	if len(actions) != len(expected) {
+		t.Fatal("Actions MUST be ", expected, ", but found: ", actions)
+	}
+
+	for index, action := range expected {
+		if actions[index] != action {
+			t.Error("Action MUST be ", action, ", but found: ", actions[index])
+		}
+	}
+
+	if codes[1] != http.StatusForbidden || len(trashed) != 1 || trashed[0] != "E1" {
+		t.Error("Trash of the protected entity MUST be forbidden, but found: ", codes, trashed)
+	}
+}
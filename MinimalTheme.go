@@ -0,0 +1,168 @@
+package crud
+
+import (
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// minimalTheme is a theme without a CSS framework, with small
+// crud-* styles and a shim for the Bootstrap components used by the
+// scripts of the screens: modals, dropdowns, collapses and tabs
+type minimalTheme struct{}
+
+var _ Theme = minimalTheme{}
+
+// NewMinimalTheme returns the theme without a CSS framework, e.g. for
+// pages embedded in applications with their own styles
+func NewMinimalTheme() Theme {
+	return minimalTheme{}
+}
+
+func (theme minimalTheme) Page(page ThemePage) string {
+	webpage := hb.Webpage()
+	webpage.SetTitle(page.Title)
+
+	webpage.AddStyleURLs(theme.StyleURLs())
+	webpage.AddScriptURLs(theme.ScriptURLs())
+	webpage.AddStyle(theme.Style())
+	webpage.AddScript(theme.Script())
+	webpage.Child(hb.Raw(page.Content))
+	webpage.AddStyleURLs(page.StyleURLs)
+	webpage.AddStyle(page.Style)
+	webpage.AddScriptURLs(page.ScriptURLs)
+	webpage.AddScript(page.Script)
+
+	return webpage.ToHTML()
+}
+
+func (theme minimalTheme) StyleURLs() []string {
+	return []string{}
+}
+
+func (theme minimalTheme) ScriptURLs() []string {
+	return []string{}
+}
+
+func (theme minimalTheme) Style() string {
+	return `
+body{margin:0;font-family:system-ui,sans-serif;font-size:15px;line-height:1.5;color:#222;}
+.container{max-width:1140px;margin:0 auto;padding:0 15px;}
+.row{display:flex;flex-wrap:wrap;gap:0 16px;}
+.row>[class*="col"]{flex:1 1 0;min-width:200px;}
+.float-end{float:right;}
+.d-flex{display:flex;}
+.table{width:100%;border-collapse:collapse;}
+.table th,.table td{padding:6px 8px;border-bottom:1px solid #ddd;text-align:left;}
+.breadcrumb{display:flex;gap:8px;list-style:none;padding:0;}
+.breadcrumb-item+.breadcrumb-item::before{content:"/";margin-right:8px;color:#888;}
+.nav,.navbar-nav{display:flex;gap:8px;list-style:none;padding:0;margin:0;}
+.collapse:not(.show),.tab-pane:not(.active){display:none;}
+.dropdown{position:relative;display:inline-block;}
+.dropdown-menu{display:none;position:absolute;right:0;z-index:10;min-width:160px;padding:4px 0;list-style:none;background:#fff;border:1px solid #ccc;}
+.dropdown-menu.show{display:block;}
+.dropdown-item{display:block;padding:4px 12px;color:inherit;text-decoration:none;}
+.crud-input{box-sizing:border-box;width:100%;padding:6px 8px;font:inherit;border:1px solid #bbb;border-radius:3px;}
+.crud-field{margin-top:12px;}
+.crud-field>label{display:block;margin-bottom:4px;}
+.crud-help{margin:4px 0 0;color:#666;font-size:13px;}
+.crud-btn{display:inline-block;padding:6px 12px;font:inherit;color:#222;text-decoration:none;background:#eee;border:1px solid #bbb;border-radius:3px;cursor:pointer;}
+.crud-btn-sm{padding:2px 8px;font-size:13px;}
+.crud-btn-primary{color:#fff;background:#2563eb;border-color:#2563eb;}
+.crud-btn-success{color:#fff;background:#16a34a;border-color:#16a34a;}
+.crud-btn-danger{color:#fff;background:#dc2626;border-color:#dc2626;}
+.crud-btn-link{background:none;border-color:transparent;color:#2563eb;}
+[class*="crud-btn-outline-"]{background:#fff;}
+.crud-alert{padding:10px 14px;margin:10px 0;border:1px solid #bbb;border-radius:3px;}
+.crud-alert-danger{color:#991b1b;background:#fee2e2;border-color:#fca5a5;}
+.crud-alert-success{color:#166534;background:#dcfce7;border-color:#86efac;}
+.crud-alert-info{color:#1e40af;background:#dbeafe;border-color:#93c5fd;}
+.crud-modal{display:none;position:fixed;inset:0;z-index:20;overflow:auto;background:rgba(0,0,0,.4);}
+.crud-modal.show{display:block;}
+.crud-modal-dialog{max-width:500px;margin:40px auto;background:#fff;border-radius:4px;}
+.crud-modal-lg{max-width:800px;}
+.crud-modal-header,.crud-modal-body,.crud-modal-footer{padding:12px 16px;}
+.crud-modal-header h5{margin:0;}
+.crud-modal-footer{display:flex;justify-content:flex-end;gap:8px;border-top:1px solid #ddd;}
+`
+}
+
+// Script returns the shim of the Bootstrap components, only defined if
+// Bootstrap is not loaded by the page
+func (theme minimalTheme) Script() string {
+	return `
+if (!window.bootstrap) {
+	const instances = new WeakMap();
+	class Modal {
+		constructor(element) {
+			this.element = element;
+			instances.set(element, this);
+		}
+		static getInstance(element) {
+			return instances.get(element) || null;
+		}
+		show() {
+			this.element.classList.add('show');
+		}
+		hide() {
+			this.element.classList.remove('show');
+		}
+	}
+	window.bootstrap = {Modal: Modal};
+	document.addEventListener('click', (event) => {
+		const dismiss = event.target.closest('[data-bs-dismiss="modal"]');
+		if (dismiss) {
+			dismiss.closest('.crud-modal').classList.remove('show');
+			return;
+		}
+		const toggle = event.target.closest('[data-bs-toggle]');
+		document.querySelectorAll('.dropdown-menu.show').forEach((menu) => {
+			if (!toggle || menu.previousElementSibling !== toggle) menu.classList.remove('show');
+		});
+		if (!toggle) {
+			return;
+		}
+		event.preventDefault();
+		const type = toggle.getAttribute('data-bs-toggle');
+		const target = document.querySelector(toggle.getAttribute('data-bs-target'));
+		if (type === 'dropdown') {
+			toggle.nextElementSibling.classList.toggle('show');
+		} else if (type === 'collapse' && target) {
+			target.classList.toggle('show');
+		} else if (type === 'tab' && target) {
+			Array.from(target.parentElement.children).forEach((pane) => pane.classList.remove('active', 'show'));
+			toggle.closest('.nav').querySelectorAll('[data-bs-toggle="tab"]').forEach((tab) => tab.classList.remove('active'));
+			target.classList.add('active', 'show');
+			toggle.classList.add('active');
+		}
+	});
+}
+`
+}
+
+func (theme minimalTheme) InputClass(fieldType string) string {
+	return "crud-input"
+}
+
+func (theme minimalTheme) FormGroup(label hb.TagInterface, input hb.TagInterface, help string) *hb.Tag {
+	return hb.Div().
+		Class("crud-field").
+		ChildIf(label != nil, label).
+		Child(input).
+		ChildIf(help != "", hb.Paragraph().Class("crud-help").HTML(help))
+}
+
+func (theme minimalTheme) ButtonClass(variant string, small bool) string {
+	return "crud-btn crud-btn-" + variant + lo.Ternary(small, " crud-btn-sm", "")
+}
+
+func (theme minimalTheme) AlertClass(variant string) string {
+	return "crud-alert crud-alert-" + variant
+}
+
+func (theme minimalTheme) Modal(modal ThemeModal) hb.TagInterface {
+	return hb.Div().ID(modal.ID).Class("crud-modal").
+		Child(hb.Div().Class("crud-modal-dialog").ClassIf(modal.Large, "crud-modal-lg").
+			Child(hb.Div().Class("crud-modal-header").Child(hb.Heading5().Text(modal.Title))).
+			Child(hb.Div().Class("crud-modal-body").Children(modal.Body)).
+			Child(hb.Div().Class("crud-modal-footer").Children(modal.Footer)))
+}
@@ -0,0 +1,56 @@
+package crud
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// EncodeMultiValue encodes the values of a multi-valued field
+// (multiselect, checkboxes, tags) as a JSON array, which is the
+// format used in the data maps passed to FuncCreate and FuncUpdate.
+//
+// Parameters:
+// - values: the selected values
+//
+// Returns:
+// - string - a JSON array, e.g. ["a","b"]
+func EncodeMultiValue(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+
+	encoded, err := json.Marshal(values)
+
+	if err != nil {
+		return "[]"
+	}
+
+	return string(encoded)
+}
+
+// DecodeMultiValue decodes the value of a multi-valued field.
+//
+// A JSON array is decoded as is, an empty string is an empty list,
+// and any other string is treated as a single value.
+//
+// Parameters:
+// - value: the encoded value
+//
+// Returns:
+// - []string - the values
+func DecodeMultiValue(value string) []string {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return []string{}
+	}
+
+	if strings.HasPrefix(value, "[") {
+		values := []string{}
+		if err := json.Unmarshal([]byte(value), &values); err == nil {
+			return values
+		}
+	}
+
+	return []string{value}
+}
@@ -0,0 +1,70 @@
+package crud
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestMultiValueEncodeDecode(t *testing.T) {
+	encoded := EncodeMultiValue([]string{"red", "green"})
+
+	if encoded != `["red","green"]` {
+		t.Error(`Encoded value MUST be ["red","green"], but found: `, encoded)
+	}
+
+	decoded := DecodeMultiValue(encoded)
+
+	if len(decoded) != 2 || decoded[0] != "red" || decoded[1] != "green" {
+		t.Error("Decoded value MUST be [red green], but found: ", decoded)
+	}
+
+	if EncodeMultiValue(nil) != "[]" {
+		t.Error("Encoded nil MUST be [], but found: ", EncodeMultiValue(nil))
+	}
+
+	if len(DecodeMultiValue("")) != 0 {
+		t.Error("Decoded empty string MUST be an empty list")
+	}
+
+	if single := DecodeMultiValue("blue"); len(single) != 1 || single[0] != "blue" {
+		t.Error("Decoded plain string MUST be a single value, but found: ", single)
+	}
+}
+
+func TestRequestValueMultiValued(t *testing.T) {
+	form := url.Values{}
+	form.Add("colors[]", "red")
+	form.Add("colors[]", "green")
+	form.Add("sizes", `["s","m"]`)
+	form.Add("tags", "go, crud,go")
+	form.Add("active", "0")
+	form.Add("active", "on")
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	expected := map[string]string{
+		"colors": `["red","green"]`,
+		"sizes":  `["s","m"]`,
+		"tags":   `["go","crud"]`,
+		"active": "1",
+		"agreed": "0",
+	}
+
+	fields := []FormField{
+		{Name: "colors", Type: FORM_FIELD_TYPE_CHECKBOXES},
+		{Name: "sizes", Type: FORM_FIELD_TYPE_MULTISELECT},
+		{Name: "tags", Type: FORM_FIELD_TYPE_TAGS},
+		{Name: "active", Type: FORM_FIELD_TYPE_SWITCH},
+		{Name: "agreed", Type: FORM_FIELD_TYPE_CHECKBOX},
+	}
+
+	for _, field := range fields {
+		value := requestValue(r, field)
+		if value != expected[field.Name] {
+			t.Error("Value of "+field.Name+" MUST be "+expected[field.Name]+", but found: ", value)
+		}
+	}
+}
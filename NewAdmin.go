@@ -0,0 +1,66 @@
+package crud
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+func NewAdmin(config AdminConfig) (admin *Admin, err error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("Endpoint is required")
+	}
+
+	if config.Menu != "" && config.Menu != ADMIN_MENU_SIDEBAR && config.Menu != ADMIN_MENU_TOPBAR {
+		return nil, errors.New("Menu " + config.Menu + " is not supported")
+	}
+
+	admin = &Admin{}
+	admin.endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	admin.funcCanAccess = config.FuncCanAccess
+	admin.funcLayout = config.FuncLayout
+	admin.funcLocale = config.FuncLocale
+	admin.homeURL = lo.Ternary(config.HomeURL == "", admin.endpoint, config.HomeURL)
+	admin.menu = lo.Ternary(config.Menu == "", ADMIN_MENU_SIDEBAR, config.Menu)
+	admin.theme = lo.Ternary(config.Theme == nil, NewBootstrapTheme(), config.Theme)
+	admin.translator = config.Translator
+	admin.title = lo.Ternary(config.Title == "", "Admin", config.Title)
+
+	for _, entity := range config.Entities {
+		if entity.Key == "" || strings.Contains(entity.Key, "/") {
+			return nil, errors.New("Key of entity " + entity.Config.EntityNamePlural + " is invalid")
+		}
+
+		if lo.SomeBy(admin.entities, func(existing adminEntity) bool { return existing.Key == entity.Key }) {
+			return nil, errors.New("Key " + entity.Key + " is not unique")
+		}
+
+		entity.Config.Endpoint = admin.endpoint + "/" + entity.Key
+		entity.Config.HomeURL = admin.homeURL
+		if entity.Config.FuncLayout == nil {
+			entity.Config.FuncLayout = config.FuncLayout
+		}
+		if entity.Config.FuncLocale == nil {
+			entity.Config.FuncLocale = config.FuncLocale
+		}
+		if entity.Config.Translator == nil {
+			entity.Config.Translator = config.Translator
+		}
+		if entity.Config.Theme == nil {
+			entity.Config.Theme = admin.theme
+		}
+
+		crud, err := NewCrud(entity.Config)
+
+		if err != nil {
+			return nil, errors.New("Entity " + entity.Key + ": " + err.Error())
+		}
+
+		crud.admin = admin
+
+		admin.entities = append(admin.entities, adminEntity{AdminEntity: entity, crud: &crud})
+	}
+
+	return admin, nil
+}
@@ -1,12 +1,21 @@
 package crud
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/samber/lo"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
 
 func NewCrud(config CrudConfig) (crud Crud, err error) {
-	if config.FuncRows == nil {
+	if config.FuncRows == nil && config.FuncRowsQuery == nil {
 		return Crud{}, errors.New("FuncRows function is required")
 	}
 
+	if len(config.Filters) > 0 && config.FuncRowsQuery == nil {
+		return Crud{}, errors.New("FuncRowsQuery function is required by Filters")
+	}
+
 	if config.UpdateFields == nil {
 		return Crud{}, errors.New("UpdateFields is required")
 	}
@@ -17,23 +26,71 @@ func NewCrud(config CrudConfig) (crud Crud, err error) {
 		return Crud{}, errors.New("FuncUpdate function is required")
 	}
 
+	if config.FuncUpdateChanges != nil && config.FuncFetchUpdateData == nil {
+		return Crud{}, errors.New("FuncFetchUpdateData function is required by FuncUpdateChanges")
+	}
+
+	if config.PreferencesStore != nil && config.FuncUserID == nil {
+		return Crud{}, errors.New("FuncUserID function is required by PreferencesStore")
+	}
+
+	if lo.SomeBy(config.Middlewares, func(middleware Middleware) bool { return middleware.Handler == nil }) {
+		return Crud{}, errors.New("Handler of the middlewares is required")
+	}
+
+	if err := checkInlineColumns(config.Columns, config.UpdateFields); err != nil {
+		return Crud{}, err
+	}
+
+	jsonSchemas := map[string]*jsonschema.Schema{}
+	if err := compileJSONSchemas(append(append([]FormField{}, config.CreateFields...), config.UpdateFields...), jsonSchemas); err != nil {
+		return Crud{}, err
+	}
+
 	crud = Crud{}
-	crud.columnNames = config.ColumnNames
+	crud.children = config.Children
+	crud.columns = config.Columns
+	if len(crud.columns) == 0 {
+		crud.columns = lo.Map(config.ColumnNames, func(columnName string, _ int) Column {
+			return Column{Name: columnName}
+		})
+	}
 	crud.createFields = config.CreateFields
 	crud.endpoint = config.Endpoint
 	crud.entityNamePlural = config.EntityNamePlural
 	crud.entityNameSingular = config.EntityNameSingular
+	crud.fieldGroups = config.FieldGroups
 	crud.fileManagerURL = config.FileManagerURL
+	crud.filters = config.Filters
 	crud.funcCreate = config.FuncCreate
+	crud.funcCreateDefaults = config.FuncCreateDefaults
+	crud.funcDuplicate = config.FuncDuplicate
 	crud.funcReadExtras = config.FuncReadExtras
 	crud.funcFetchReadData = config.FuncFetchReadData
+	crud.funcFetchReadValues = config.FuncFetchReadValues
 	crud.funcFetchUpdateData = config.FuncFetchUpdateData
+	crud.funcFetchLabels = config.FuncFetchLabels
 	crud.funcLayout = config.FuncLayout
+	crud.funcLocale = config.FuncLocale
 	crud.funcRows = config.FuncRows
+	crud.funcRowsQuery = config.FuncRowsQuery
+	crud.funcRowsByParent = config.FuncRowsByParent
+	crud.funcSearch = config.FuncSearch
+	crud.funcTimezone = config.FuncTimezone
 	crud.funcTrash = config.FuncTrash
 	crud.funcUpdate = config.FuncUpdate
+	crud.funcUpdateChanges = config.FuncUpdateChanges
+	crud.funcUserID = config.FuncUserID
 	crud.homeURL = config.HomeURL
+	crud.jsonSchemas = jsonSchemas
+	crud.middlewares = config.Middlewares
+	crud.parentKey = config.ParentKey
+	crud.pathRouting = config.PathRouting
+	crud.preferencesStore = config.PreferencesStore
 	crud.readFields = config.ReadFields
+	crud.theme = lo.Ternary(config.Theme == nil, NewBootstrapTheme(), config.Theme)
+	crud.timezone = config.Timezone
+	crud.translator = config.Translator
 	crud.updateFields = config.UpdateFields
 
 	return crud, err
@@ -0,0 +1,43 @@
+package crud
+
+import "sync"
+
+// PreferencesStore persists the preferences of the users, such as the
+// saved views of the entity manager. The values are opaque strings,
+// keyed by user ID and preference key.
+type PreferencesStore interface {
+	// Get returns the value of the preference of the user,
+	// or an empty string if not set
+	Get(userID string, key string) (string, error)
+
+	// Set sets the value of the preference of the user
+	Set(userID string, key string, value string) error
+}
+
+// NewMemoryPreferencesStore returns a PreferencesStore keeping the
+// preferences in memory, e.g. for development and tests. The
+// preferences are lost when the application restarts.
+func NewMemoryPreferencesStore() PreferencesStore {
+	return &memoryPreferencesStore{values: map[string]string{}}
+}
+
+type memoryPreferencesStore struct {
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+func (store *memoryPreferencesStore) Get(userID string, key string) (string, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.values[userID+"\x00"+key], nil
+}
+
+func (store *memoryPreferencesStore) Set(userID string, key string, value string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.values[userID+"\x00"+key] = value
+
+	return nil
+}
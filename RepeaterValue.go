@@ -0,0 +1,77 @@
+package crud
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// EncodeRepeaterValue encodes the items of a repeater field as a JSON
+// array of objects, which is the format used in the data maps passed
+// to FuncCreate and FuncUpdate.
+//
+// Parameters:
+// - items: the items, each keyed by the names of the nested fields
+//
+// Returns:
+// - string - a JSON array, e.g. [{"city":"London"},{"city":"Paris"}]
+func EncodeRepeaterValue(items []map[string]string) string {
+	if items == nil {
+		items = []map[string]string{}
+	}
+
+	encoded, err := json.Marshal(items)
+
+	if err != nil {
+		return "[]"
+	}
+
+	return string(encoded)
+}
+
+// DecodeRepeaterValue decodes the value of a repeater field, as encoded
+// by EncodeRepeaterValue. Numbers are converted to strings and booleans
+// to "1" or "0", as posted by the nested fields.
+//
+// Parameters:
+// - value: the JSON array of objects
+//
+// Returns:
+// - []map[string]string - the items, empty if the value is not valid
+func DecodeRepeaterValue(value string) []map[string]string {
+	value = strings.TrimSpace(value)
+	items := []map[string]string{}
+
+	if !strings.HasPrefix(value, "[") {
+		return items
+	}
+
+	decoded := []map[string]any{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return items
+	}
+
+	for _, object := range decoded {
+		item := map[string]string{}
+
+		for key, itemValue := range object {
+			switch typed := itemValue.(type) {
+			case nil:
+				item[key] = ""
+			case bool:
+				item[key] = map[bool]string{true: "1", false: "0"}[typed]
+			case string:
+				item[key] = typed
+			case float64:
+				item[key] = strconv.FormatFloat(typed, 'f', -1, 64)
+			case []any, map[string]any:
+				encoded, _ := json.Marshal(typed)
+				item[key] = string(encoded)
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
@@ -0,0 +1,58 @@
+package crud
+
+// RowsQuery is the query of the rows of the entity manager table,
+// passed to FuncRowsQuery
+type RowsQuery struct {
+	// Filters are the active filters, in the order of the Filters
+	// of the configuration
+	Filters []FilterValue
+
+	// Sort is the name of the column the rows are sorted by,
+	// empty if not sorted
+	Sort string
+
+	// SortDescending is true if the rows are sorted in descending order
+	SortDescending bool
+
+	// Columns are the names of the visible columns, in the order shown.
+	// These are the columns of the URL or of the view, else the columns
+	// chosen by the user, else the columns which are not Hidden.
+	Columns []string
+}
+
+// FilterValue is the value of an active filter
+type FilterValue struct {
+	// Name is the name of the filter
+	Name string
+
+	// Type is the type of the filter, one of the FILTER_TYPE_* constants
+	Type string
+
+	// Value is the value of the text, select and boolean filters.
+	// The boolean filters are either "1" or "0"
+	Value string
+
+	// From is the start of the range filters, empty if not bounded
+	From string
+
+	// To is the end of the range filters, empty if not bounded
+	To string
+}
+
+// Filter returns the value of the active filter with the name
+//
+// Parameters:
+// - name: the name of the filter
+//
+// Returns:
+// - FilterValue - the value of the filter
+// - bool - true if the filter is active
+func (query RowsQuery) Filter(name string) (FilterValue, bool) {
+	for _, filter := range query.Filters {
+		if filter.Name == name {
+			return filter, true
+		}
+	}
+
+	return FilterValue{}, false
+}
@@ -0,0 +1,77 @@
+package crud
+
+import (
+	"net/http"
+
+	"github.com/gouniverse/hb"
+)
+
+// Theme renders the parts of the CRUD screens specific to a CSS
+// framework: the page shell, the form controls, the buttons, the alerts
+// and the modals. NewBootstrapTheme is the default, NewMinimalTheme has
+// no framework.
+type Theme interface {
+	// Page returns the HTML of the page, the shell around the content
+	// with the assets of the theme and of the page
+	Page(page ThemePage) string
+
+	// StyleURLs returns the stylesheets of the framework of the theme,
+	// to be included by the layouts set with FuncLayout
+	StyleURLs() []string
+
+	// ScriptURLs returns the scripts of the framework of the theme,
+	// to be included by the layouts set with FuncLayout
+	ScriptURLs() []string
+
+	// Style returns the CSS the screens need on top of the framework,
+	// added to the pages of FuncLayout too
+	Style() string
+
+	// Script returns the JavaScript the screens need on top of the
+	// framework, added to the pages of FuncLayout too
+	Script() string
+
+	// InputClass returns the class of the inputs of the field type,
+	// one of the FORM_FIELD_TYPE_* constants
+	InputClass(fieldType string) string
+
+	// FormGroup returns the markup of a form field, with its label,
+	// its input and its help, the label and the help being optional
+	FormGroup(label hb.TagInterface, input hb.TagInterface, help string) *hb.Tag
+
+	// ButtonClass returns the class of the buttons of the variant,
+	// one of the BUTTON_* constants
+	ButtonClass(variant string, small bool) string
+
+	// AlertClass returns the class of the alerts of the variant,
+	// one of the ALERT_* constants
+	AlertClass(variant string) string
+
+	// Modal returns the markup of a modal, opened and closed with
+	// bootstrap.Modal by the scripts of the screens
+	Modal(modal ThemeModal) hb.TagInterface
+}
+
+// ThemePage is a page rendered by Theme.Page
+type ThemePage struct {
+	// Request is the HTTP request of the page
+	Request *http.Request
+
+	Title      string
+	Content    string
+	StyleURLs  []string
+	Style      string
+	ScriptURLs []string
+	Script     string
+}
+
+// ThemeModal is a modal rendered by Theme.Modal
+type ThemeModal struct {
+	ID     string
+	Title  string
+	Body   []hb.TagInterface
+	Footer []hb.TagInterface
+
+	// Large makes the modal wider, e.g. for forms with columns
+	Large bool
+}
@@ -0,0 +1,73 @@
+package crud
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestThemes(t *testing.T) {
+	config := CrudConfig{
+		Endpoint:           "/users",
+		EntityNameSingular: "User",
+		EntityNamePlural:   "Users",
+		CreateFields:       []FormField{{Type: FORM_FIELD_TYPE_STRING, Name: "name", Label: "Name", Help: "The full name"}},
+		UpdateFields:       []FormField{},
+		FuncCreate: func(data map[string]string) (string, error) {
+			return "E1", nil
+		},
+		FuncRows: func() ([]Row, error) {
+			return []Row{}, nil
+		},
+		FuncTrash: func(entityID string) error {
+			return nil
+		},
+	}
+
+	manager := func(config CrudConfig) string {
+		crud, err := NewCrud(config)
+		if err != nil {
+			t.Fatal("Error MUST be nil, but found: ", err.Error())
+		}
+
+		w := httptest.NewRecorder()
+		crud.Handler(w, httptest.NewRequest("GET", crud.UrlEntityManager(), nil))
+
+		return w.Body.String()
+	}
+
+	bootstrap := manager(config)
+
+	for _, expected := range []string{
+		"bootstrap.min.css",
+		`class="btn btn-success float-end"`,
+		`class="modal-dialog"`,
+		`class="form-group mt-3"`,
+		`class="form-control"`,
+		`<p class="text-info">The full name</p>`,
+	} {
+		if !strings.Contains(bootstrap, expected) {
+			t.Fatal("Bootstrap page MUST contain", expected)
+		}
+	}
+
+	config.Theme = NewMinimalTheme()
+	minimal := manager(config)
+
+	for _, expected := range []string{
+		`class="crud-btn crud-btn-success float-end"`,
+		`class="crud-modal"`,
+		`class="crud-field"`,
+		`class="crud-input"`,
+		`<p class="crud-help">The full name</p>`,
+		"window.bootstrap = {Modal: Modal};",
+	} {
+		if !strings.Contains(minimal, expected) {
+			t.Fatal("Minimal page MUST contain", expected)
+		}
+	}
+
+	if strings.Contains(minimal, "bootstrap.min.css") || strings.Contains(minimal, `"btn `) {
+		t.Fatal("Minimal page MUST NOT contain Bootstrap")
+	}
+}
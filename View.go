@@ -0,0 +1,15 @@
+package crud
+
+// View is a saved view of the entity manager, storing the visible
+// columns, their order, the filters and the sort under a name
+type View struct {
+	// Name is the name of the view, unique per user
+	Name string
+
+	// IsDefault is true if the view is applied when the entity manager
+	// is opened without a view. A single view is the default.
+	IsDefault bool
+
+	// Query is the query of the rows of the view
+	Query RowsQuery
+}
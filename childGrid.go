@@ -0,0 +1,259 @@
+package crud
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/icons"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+var errFuncRowsByParentRequired = errors.New("FuncRowsByParent function is required")
+
+// childGrids generates the grids of the child entities of the
+// specified parent, shown on the read and update pages of the parent.
+//
+// Each grid is a separate Vue app, so it must be placed outside
+// of the Vue app of the page.
+//
+// Parameters:
+// - r: the HTTP request
+// - parentID: the ID of the parent entity
+//
+// Returns:
+// - []hb.TagInterface - the grids
+// - string - the JavaScript code mounting the grids
+func (crud *Crud) childGrids(r *http.Request, parentID string) ([]hb.TagInterface, string) {
+	grids := []hb.TagInterface{}
+	script := ""
+
+	for index, child := range crud.children {
+		if child == nil {
+			continue
+		}
+
+		// the child is shown in the locale of the request of the parent
+		child := *child
+		child.locale = crud.locale
+
+		gridID := "EntityChild" + utils.ToString(index)
+		grids = append(grids, child.childGrid(r, gridID, parentID))
+		script += child.childGridScript(r, gridID, parentID)
+	}
+
+	return grids, script
+}
+
+// childGrid generates the grid of the entities of this (child) Crud
+// belonging to the specified parent, with the modals for creating
+// and editing the entities inline
+func (crud *Crud) childGrid(r *http.Request, gridID string, parentID string) hb.TagInterface {
+	buttonCreate := hb.Button().
+		Class(crud.theme.ButtonClass(BUTTON_SUCCESS, true)+" float-end").
+		Attr("v-on:click", "showEntityCreateModal").
+		AddChild(icons.Icon("bi-plus-circle", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
+		HTML(crud.tEntity("New {name}"))
+
+	rows := []Row{}
+	var errRows error
+	if crud.funcRowsByParent == nil {
+		errRows = errFuncRowsByParentRequired
+	} else {
+		rows, errRows = crud.funcRowsByParent(parentID)
+	}
+
+	// the columns and the cells are those of the entity manager table
+	columns := crud.visibleColumns(RowsQuery{Columns: crud.userColumns(r)})
+	relationLabels := crud.relationLabels(rows)
+
+	tableContent := lo.IfF(errRows != nil, func() hb.TagInterface {
+		return hb.Div().
+			Class(crud.theme.AlertClass(ALERT_DANGER)).
+			HTML(crud.t("There was an error retrieving the data. Please try again later"))
+	}).ElseF(func() hb.TagInterface {
+		return hb.Table().
+			Class("table table-striped mb-0").
+			Child(hb.Thead().Child(hb.TR().
+				Children(crud.tableHeaders(columns)).
+				Child(hb.TH().HTML(crud.t("Actions")).Style("width:100px;")))).
+			Child(hb.Tbody().Children(lo.Map(rows, func(row Row, _ int) hb.TagInterface {
+				buttonEdit := hb.Button().
+					Class(crud.theme.ButtonClass(BUTTON_OUTLINE_WARNING, true)).
+					Style("margin-right:5px").
+					Attr("title", crud.t("Edit")).
+					Attr("type", "button").
+					Attr("v-on:click", "showEntityUpdateModal('"+row.ID+"')").
+					Child(icons.Icon("bi-pencil-square", 18, 18, "#333").Style("margin-top:-4px;"))
+
+				buttonTrash := hb.Button().
+					Class(crud.theme.ButtonClass(BUTTON_OUTLINE_DANGER, true)).
+					Attr("title", crud.t("Trash")).
+					Attr("type", "button").
+					Attr("v-on:click", "entityTrash('"+row.ID+"')").
+					Child(icons.Icon("bi-trash", 18, 18, "#333").Style("margin-top:-4px;"))
+
+				return hb.TR().
+					Children(crud.tableCells(r, columns, row, relationLabels, false)).
+					Child(hb.TD().
+						Style(`white-space:nowrap;`).
+						ChildIf(crud.isUpdateEnabled(), buttonEdit).
+						ChildIf(crud.funcTrash != nil, buttonTrash))
+			})))
+	})
+
+	card := hb.Div().
+		Class("card mt-3").
+		Child(hb.Div().
+			Class("card-header").
+			Style(`display:flex;justify-content:space-between;align-items:center;`).
+			Child(hb.Heading4().
+				HTML(crud.entityNamePlural).
+				Style("margin-bottom:0;display:inline-block;")).
+			ChildIf(crud.funcCreate != nil, buttonCreate)).
+		Child(hb.Div().
+			Class("card-body").
+			Child(tableContent))
+
+	return hb.Div().
+		ID(gridID).
+		Class("container").
+		Child(card).
+		Child(crud.childGridModal(gridID+"Create", crud.tEntity("New {name}"), requestFields(r, crud.createFields), "entityCreate")).
+		Child(crud.childGridModal(gridID+"Update", crud.tEntity("Edit {name}"), requestFields(r, crud.updateFields), "entityUpdate"))
+}
+
+// childGridModal generates a modal with a form for the child grid
+func (crud *Crud) childGridModal(modalID string, title string, fields []FormField, saveMethod string) hb.TagInterface {
+	return crud.theme.Modal(ThemeModal{
+		ID:    modalID,
+		Title: title,
+		Body:  crud.form(fields),
+		Footer: []hb.TagInterface{
+			hb.Button().Text(crud.t("Close")).Class(crud.theme.ButtonClass(BUTTON_SECONDARY, false)).Attr("data-bs-dismiss", "modal"),
+			hb.Button().Text(crud.t("Save")).Class(crud.theme.ButtonClass(BUTTON_PRIMARY, false)).Attr("v-on:click", saveMethod),
+		},
+		Large: isWideForm(fields),
+	})
+}
+
+// childGridScript generates the JavaScript code mounting the Vue app
+// of the child grid
+func (crud *Crud) childGridScript(r *http.Request, gridID string, parentID string) string {
+	createFields := requestFields(r, crud.createFields)
+	updateFields := requestFields(r, crud.updateFields)
+
+	createValues, _ := crud.createValues(r, createFields, false)
+
+	tmpValues := crud.formState(updateFields, map[string]string{})
+	for key, value := range crud.formState(createFields, createValues) {
+		tmpValues[key] = value
+	}
+
+	dependentOptions := crud.dependentOptionsConfig(createFields, "create")
+	for _, config := range crud.dependentOptionsConfig(updateFields, "update") {
+		if _, found := fieldByName(createFields, config["field"].(string)); !found {
+			dependentOptions = append(dependentOptions, config)
+		}
+	}
+
+	computed := computedConfig(createFields)
+	for _, config := range computedConfig(updateFields) {
+		if _, found := fieldByName(createFields, config["field"].(string)); !found {
+			computed = append(computed, config)
+		}
+	}
+
+	jsonConfig, _ := utils.ToJSON(map[string]any{
+		"gridId":           gridID,
+		"createUrl":        crud.UrlEntityCreateAjax(),
+		"fetchUrl":         crud.UrlEntityFetchAjax(),
+		"updateUrl":        crud.UrlEntityUpdateAjax(),
+		"trashUrl":         crud.UrlEntityTrashAjax(),
+		"parentKey":        crud.parentKey,
+		"parentId":         parentID,
+		"createValues":     formModel(createFields, createValues),
+		"tmpValues":        tmpValues,
+		"dependentOptions": dependentOptions,
+		"computed":         computed,
+	})
+
+	return `
+(() => {
+	const config = ` + jsonConfig + `;
+	const reload = () => setTimeout(() => {location.href = location.href;}, 1000);
+	const modal = (name) => {
+		const element = document.getElementById(config.gridId + name);
+		return bootstrap.Modal.getInstance(element) || new bootstrap.Modal(element);
+	};
+	const fail = (result) => Swal.fire({icon: 'error', title: crudT('Oops...'), text: result.message || result});
+	const EntityChild = {
+		data() {
+			return {
+				entityModel:{},
+				entityId:null,
+				tmp:JSON.parse(JSON.stringify(config.tmpValues)),
+			}
+		},
+		mounted(){
+			this.optionsWatch(config.dependentOptions);
+			this.computedWatch(config.computed);
+		},
+		methods: {` + scriptFormMethods + `
+			showEntityCreateModal(){
+				this.entityModel = JSON.parse(JSON.stringify(config.createValues));
+				modal('Create').show();
+			},
+			entityCreate(){
+				const data = crudSerializeModel(this.entityModel);
+				if (config.parentKey !== "") data[config.parentKey] = config.parentId;
+				$.post(config.createUrl, data).done((response)=>{
+					if (response.status !== "success") return fail(response);
+					modal('Create').hide();
+					Swal.fire({icon: 'success', title: crudT('Entity saved')});
+					reload();
+				}).fail(fail);
+			},
+			showEntityUpdateModal(entityId){
+				$.get(config.fetchUrl, {entity_id: entityId}).done((response)=>{
+					if (response.status !== "success") return fail(response);
+					this.entityId = entityId;
+					this.entityModel = response.data.model;
+					Object.assign(this.tmp, response.data.tmp);
+					modal('Update').show();
+				}).fail(fail);
+			},
+			entityUpdate(){
+				const data = crudSerializeModel(this.entityModel);
+				data["entity_id"] = this.entityId;
+				$.post(config.updateUrl, data).done((response)=>{
+					if (response.status !== "success") return fail(response);
+					modal('Update').hide();
+					Swal.fire({icon: 'success', title: crudT('Entity saved')});
+					reload();
+				}).fail(fail);
+			},
+			entityTrash(entityId){
+				Swal.fire({
+					icon: 'warning',
+					text: crudT('Are you sure you want to move this entity to trash bin?'),
+					showCancelButton: true,
+					confirmButtonText: crudT('Move to trash bin'),
+				}).then((result) => {
+					if (!result.value) return;
+					$.post(config.trashUrl, {entity_id: entityId}).done((response)=>{
+						if (response.status !== "success") return fail(response);
+						Swal.fire({icon: 'success', title: crudT('Entity trashed')});
+						reload();
+					}).fail(fail);
+				});
+			},
+		}
+	};
+	const app = Vue.createApp(EntityChild);
+	if (window.ElementPlus) app.use(ElementPlus);
+	app.mount('#' + config.gridId);
+})();
+`
+}
@@ -0,0 +1,222 @@
+package crud
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/api"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/icons"
+	"github.com/samber/lo"
+)
+
+// defaultColumns returns the names of the columns which are not
+// Hidden, in the configured order
+func (crud *Crud) defaultColumns() []string {
+	return lo.FilterMap(crud.columns, func(column Column, _ int) (string, bool) {
+		return column.label(), !column.Hidden
+	})
+}
+
+// knownColumns returns the names which are names of columns,
+// without duplicates
+func (crud *Crud) knownColumns(names []string) []string {
+	labels := lo.Map(crud.columns, func(column Column, _ int) string {
+		return column.label()
+	})
+
+	return lo.Uniq(lo.Filter(names, func(name string, _ int) bool {
+		return lo.Contains(labels, name)
+	}))
+}
+
+// userColumns returns the names of the visible columns chosen by the
+// user, stored in the PreferencesStore, or else the default columns
+func (crud *Crud) userColumns(r *http.Request) []string {
+	if !crud.isViewsEnabled() {
+		return crud.defaultColumns()
+	}
+
+	value, err := crud.preferencesStore.Get(crud.userID(r), crud.preferenceKey("columns"))
+
+	if err != nil || value == "" {
+		return crud.defaultColumns()
+	}
+
+	names := []string{}
+
+	if err := json.Unmarshal([]byte(value), &names); err != nil {
+		return crud.defaultColumns()
+	}
+
+	names = crud.knownColumns(names)
+
+	return lo.Ternary(len(names) == 0, crud.defaultColumns(), names)
+}
+
+// columnsConfig returns the columns listed in the Columns dropdown,
+// the visible ones in the order shown followed by the hidden ones
+//
+// Parameters:
+// - visible: the indexes of the visible columns
+//
+// Returns:
+// - []map[string]any - the columns, with their name and visibility
+func (crud *Crud) columnsConfig(visible []int) []map[string]any {
+	hidden := lo.Without(lo.Range(len(crud.columns)), visible...)
+
+	return lo.Map(append(append([]int{}, visible...), hidden...), func(index int, position int) map[string]any {
+		return map[string]any{
+			"name":    crud.columns[index].label(),
+			"label":   crud.t(crud.columns[index].label()),
+			"visible": position < len(visible),
+		}
+	})
+}
+
+// columnsDropdown generates the Columns dropdown of the entity manager
+// heading, showing, hiding and reordering the columns of the table
+func (crud *Crud) columnsDropdown() hb.TagInterface {
+	item := hb.LI().
+		Class("dropdown-item d-flex align-items-center").
+		Attr("v-for", "(column, index) in columns.list").
+		Attr("v-bind:key", "column.name").
+		Attr("draggable", "true").
+		Attr("v-on:dragstart", "columnDragStart(index)").
+		Attr("v-on:dragover.prevent", "").
+		Attr("v-on:drop.prevent", "columnDrop(index)").
+		Child(hb.Span().
+			Class("text-muted me-2").
+			Style("cursor:move;").
+			Attr("title", crud.t("Drag to reorder")).
+			Text("⠿")).
+		Child(hb.Label().
+			Class("form-check-label flex-grow-1").
+			Child(hb.Input().
+				Type(hb.TYPE_CHECKBOX).
+				Class("form-check-input me-2").
+				Attr("v-model", "column.visible")).
+			Text("{{ column.label }}"))
+
+	return hb.Div().
+		Class("dropdown float-end me-2").
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)+" dropdown-toggle").
+			Attr("data-bs-toggle", "dropdown").
+			Child(icons.Icon("bi-layout-three-columns", 16, 16, "#333").Style("margin-top:-4px;margin-right:8px;")).
+			Text(crud.t("Columns"))).
+		Child(hb.UL().
+			Class("dropdown-menu dropdown-menu-end").
+			Style("min-width:240px;").
+			Attr("v-on:click.stop", "").
+			Child(item).
+			Child(hb.LI().Child(hb.HR().Class("dropdown-divider"))).
+			Child(hb.LI().
+				Class("px-3").
+				Child(hb.Button().
+					Type(hb.TYPE_BUTTON).
+					Class(crud.theme.ButtonClass(BUTTON_PRIMARY, true)+" me-2").
+					Attr("v-on:click", "columnsApply(false)").
+					Text(crud.t("Apply"))).
+				Child(hb.Button().
+					Type(hb.TYPE_BUTTON).
+					Class(crud.theme.ButtonClass(BUTTON_LINK, true)).
+					Attr("v-on:click", "columnsApply(true)").
+					Text(crud.t("Reset")))))
+}
+
+// pageEntityColumnsSaveAjax saves the visible columns chosen by the
+// user, in the order shown. No columns resets them to the default.
+func (crud *Crud) pageEntityColumnsSaveAjax(w http.ResponseWriter, r *http.Request) {
+	if !crud.isViewsEnabled() {
+		api.Respond(w, r, api.Error(crud.t("Preferences are not enabled")))
+		return
+	}
+
+	r.FormValue("columns") // parses the form
+
+	value := ""
+
+	if names := crud.knownColumns(r.Form["columns[]"]); len(names) > 0 {
+		encoded, _ := json.Marshal(names)
+		value = string(encoded)
+	}
+
+	if err := crud.preferencesStore.Set(crud.userID(r), crud.preferenceKey("columns"), value); err != nil {
+		api.Respond(w, r, api.Error(crud.t("Save failed: {error}", "error", err.Error())))
+		return
+	}
+
+	api.Respond(w, r, api.Success(crud.t("Columns saved")))
+}
+
+// tableHeaders generates the headers of the visible columns of a table
+// of the entities, translated
+func (crud *Crud) tableHeaders(columns []int) []hb.TagInterface {
+	return lo.Map(columns, func(index int, _ int) hb.TagInterface {
+		return hb.TH().Text(crud.t(crud.columns[index].label()))
+	})
+}
+
+// relationLabels fetches the labels of the related entities of the
+// relation columns of the rows, keyed by column index and entity ID
+func (crud *Crud) relationLabels(rows []Row) map[int]map[string]string {
+	labels := map[int]map[string]string{}
+
+	for index, column := range crud.columns {
+		if column.Relation == nil {
+			continue
+		}
+
+		labels[index] = column.Relation.fetchLabels(lo.FilterMap(rows, func(row Row, _ int) (string, bool) {
+			if index >= len(row.Data) {
+				return "", false
+			}
+			return strings.TrimSpace(row.Data[index]), true
+		}))
+	}
+
+	return labels
+}
+
+// tableCells generates the cells of the visible columns of a row of
+// a table of the entities, formatted in the locale of the user.
+//
+// Parameters:
+// - r: the HTTP request
+// - columns: the indexes of the visible columns
+// - row: the row
+// - relationLabels: the labels of the related entities, see relationLabels
+// - isInline: true if the inline fields are editable in the cells
+//
+// Returns:
+// - []hb.TagInterface - the cells
+func (crud *Crud) tableCells(r *http.Request, columns []int, row Row, relationLabels map[int]map[string]string, isInline bool) []hb.TagInterface {
+	location := crud.location(r)
+
+	return lo.Map(columns, func(index int, _ int) hb.TagInterface {
+		column := crud.columns[index]
+		cell, _ := lo.Nth(row.Data, index)
+
+		if column.Relation != nil {
+			entityID := strings.TrimSpace(cell)
+			return hb.TD().Child(relationLink(column.Relation, entityID, relationLabels[index][entityID]))
+		}
+
+		isRaw := isRawMarked(column.Name)
+		cell = stripRawMarkers(cell)
+		formatted := crud.formatValue(column.Format, column.Currency, cell, location)
+
+		if field, editable := crud.inlineField(r, column); isInline && editable {
+			return crud.inlineCell(field, row.ID, formatted, isRaw)
+		}
+
+		// the table is sorted by the values, not by the formatted values
+		return hb.TD().
+			AttrIf(formatted != cell, "data-order", cell).
+			TextIf(!isRaw, formatted).
+			HTMLIf(isRaw, formatted)
+	})
+}
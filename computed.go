@@ -0,0 +1,74 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// computeValues sets the values of the computed fields, in the order
+// of the fields, so that each compute function sees the values of the
+// computed fields before it.
+//
+// Parameters:
+// - r: the HTTP request
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+func computeValues(r *http.Request, fields []FormField, posts map[string]string) {
+	for _, field := range fields {
+		if !field.isComputed() {
+			continue
+		}
+
+		posts[field.Name] = field.Compute(r, posts)
+	}
+}
+
+// computedConfig returns the configuration of the live previews of the
+// computed fields, as expected by the computedWatch Vue method
+func computedConfig(fields []FormField) []map[string]any {
+	configs := []map[string]any{}
+
+	for _, field := range fields {
+		if field.ComputePreview == "" || field.Name == "" {
+			continue
+		}
+
+		configs = append(configs, map[string]any{
+			"field":      field.Name,
+			"expression": field.ComputePreview,
+		})
+	}
+
+	return configs
+}
+
+// Slugify converts the text to a URL friendly slug, lowercasing it
+// and replacing each run of characters other than letters and digits
+// with a single dash. It matches the crudSlug JavaScript helper
+// available to the ComputePreview expressions.
+//
+// Parameters:
+// - text: the text to convert, e.g. "Hello, World!"
+//
+// Returns:
+// - string - the slug, e.g. "hello-world"
+func Slugify(text string) string {
+	slug := strings.Builder{}
+	dash := false
+
+	for _, char := range strings.ToLower(text) {
+		if unicode.IsLetter(char) || unicode.IsNumber(char) {
+			if dash && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(char)
+			dash = false
+			continue
+		}
+
+		dash = true
+	}
+
+	return slug.String()
+}
@@ -0,0 +1,188 @@
+package crud
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// conditionsMatch returns true if all the conditions match the values
+// of the form. The values of multi-valued fields match if any of the
+// selected values matches.
+//
+// Parameters:
+// - conditions: the conditions to check
+// - values: the values of the form, keyed by field name
+//
+// Returns:
+// - bool - true if all the conditions match, or there are no conditions
+func conditionsMatch(conditions []FieldCondition, values map[string]string) bool {
+	return lo.EveryBy(conditions, func(condition FieldCondition) bool {
+		return conditionMatch(condition, values[condition.Field])
+	})
+}
+
+// conditionMatch returns true if the condition matches the value
+func conditionMatch(condition FieldCondition, value string) bool {
+	selected := DecodeMultiValue(value)
+	isEmpty := len(lo.Compact(selected)) == 0
+
+	switch condition.Operator {
+	case CONDITION_OPERATOR_NOT_EQUALS:
+		return !lo.Contains(selected, condition.Value)
+	case CONDITION_OPERATOR_IN:
+		return len(lo.Intersect(selected, condition.Values)) > 0
+	case CONDITION_OPERATOR_NOT_IN:
+		return len(lo.Intersect(selected, condition.Values)) == 0
+	case CONDITION_OPERATOR_EMPTY:
+		return isEmpty
+	case CONDITION_OPERATOR_NOT_EMPTY:
+		return !isEmpty
+	}
+
+	return lo.Contains(selected, condition.Value)
+}
+
+// requiredFieldsError checks the required fields against the posted
+// values, skipping the fields hidden by their conditions. The items of
+// the repeater fields are checked against their nested fields.
+//
+// Parameters:
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+//
+// Returns:
+// - string - the error message, or an empty string if all is filled in
+func (crud *Crud) requiredFieldsError(fields []FormField, posts map[string]string) string {
+	for _, field := range fields {
+		if field.Name == "" || !field.isVisible(posts) {
+			continue
+		}
+
+		if field.isRepeater() {
+			if errorMessage := crud.repeaterItemsError(field, posts[field.Name]); errorMessage != "" {
+				return errorMessage
+			}
+		}
+
+		if !field.isRequired(posts) {
+			continue
+		}
+
+		if _, exists := posts[field.Name]; !exists {
+			return crud.t("{label} is required field", "label", crud.t(field.Label))
+		}
+
+		if isEmptyValue(field, posts[field.Name]) {
+			return crud.t("{label} is required field", "label", crud.t(field.Label))
+		}
+	}
+
+	return ""
+}
+
+// dependentOptions returns the options of the fields with dependent
+// options for the values of the form, keyed as options_<name> in the
+// temporary state of the Vue form
+func (crud *Crud) dependentOptions(fields []FormField, values map[string]string) map[string]any {
+	state := map[string]any{}
+
+	for _, field := range fields {
+		if !field.hasDependentOptions() {
+			continue
+		}
+
+		state["options_"+field.Name] = optionsJSON(crud.translateOptions(field.DependentOptionsF(values)))
+	}
+
+	return state
+}
+
+// dependentOptionsConfig returns the configuration of the fields with
+// dependent options, used by the Vue forms to reload the options when
+// the fields they depend on change
+func (crud *Crud) dependentOptionsConfig(fields []FormField, form string) []map[string]any {
+	configs := []map[string]any{}
+
+	for _, field := range fields {
+		if !field.hasDependentOptions() || len(field.DependsOn) == 0 {
+			continue
+		}
+
+		configs = append(configs, map[string]any{
+			"field":     field.Name,
+			"dependsOn": field.DependsOn,
+			"url":       crud.UrlEntityOptionsAjax() + "&form=" + url.QueryEscape(form) + "&field=" + url.QueryEscape(field.Name),
+		})
+	}
+
+	return configs
+}
+
+// formState returns the initial temporary state of the Vue form,
+// holding the state of the relation fields, the dependent options
+// and the Markdown editors
+func (crud *Crud) formState(fields []FormField, values map[string]string) map[string]any {
+	state := relationState(fields, values)
+
+	for key, value := range crud.dependentOptions(fields, values) {
+		state[key] = value
+	}
+
+	for key, value := range markdownState(fields) {
+		state[key] = value
+	}
+
+	return state
+}
+
+// optionsJSON converts the options to the format used by the Vue forms
+func optionsJSON(options []FormFieldOption) []map[string]string {
+	return lo.Map(options, func(option FormFieldOption, _ int) map[string]string {
+		return map[string]string{"key": option.Key, "value": option.Value}
+	})
+}
+
+// formValues reads the posted values of all the named fields
+func formValues(r *http.Request, fields []FormField) map[string]string {
+	values := map[string]string{}
+
+	for _, field := range fields {
+		if field.Name == "" {
+			continue
+		}
+		values[field.Name] = requestValue(r, field)
+	}
+
+	return values
+}
+
+// requiredMarker returns the asterisk shown next to the label of
+// a required field, toggled by the RequiredIf conditions if set
+func requiredMarker(field FormField) hb.TagInterface {
+	marker := hb.Sup().Text("*").Class("text-danger ml-1")
+
+	if field.Required {
+		return marker
+	}
+
+	if len(field.RequiredIf) == 0 {
+		return nil
+	}
+
+	requiredIf, _ := utils.ToJSON(field.RequiredIf)
+	return marker.Attr("v-if", "conditionsMatch("+requiredIf+")")
+}
+
+// dependentOptionsTag returns the options of a select loaded
+// for the current values of the form
+func dependentOptionsTag(field FormField) hb.TagInterface {
+	return hb.Option().
+		Attr("v-for", "option in tmp.options_"+field.Name).
+		Attr("v-bind:key", "option.key").
+		Attr("v-bind:value", "option.key").
+		Text("{{ option.value }}")
+}
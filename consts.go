@@ -1,11 +1,20 @@
 package crud
 
+const pathEntityColumnsSaveAjax = "entity-columns-save-ajax"
 const pathEntityCreateAjax = "entity-create-ajax"
+const pathEntityExport = "entity-export"
+const pathEntityFetchAjax = "entity-fetch-ajax"
+const pathEntityInlineUpdateAjax = "entity-inline-update-ajax"
 const pathEntityManager = "entity-manager"
+const pathEntityMarkdownPreviewAjax = "entity-markdown-preview-ajax"
+const pathEntityOptionsAjax = "entity-options-ajax"
 const pathEntityRead = "entity-read"
 const pathEntityUpdate = "entity-update"
 const pathEntityUpdateAjax = "entity-update-ajax"
 const pathEntityTrashAjax = "entity-trash-ajax"
+const pathEntitySearchAjax = "entity-search-ajax"
+const pathEntityViewDeleteAjax = "entity-view-delete-ajax"
+const pathEntityViewSaveAjax = "entity-view-save-ajax"
 
 const FORM_FIELD_TYPE_NUMBER = "number"
 const FORM_FIELD_TYPE_STRING = "string"
@@ -16,5 +25,93 @@ const FORM_FIELD_TYPE_IMAGE_INLINE = "image_inline"
 const FORM_FIELD_TYPE_HTMLAREA = "htmlarea"
 const FORM_FIELD_TYPE_BLOCKAREA = "blockarea"
 const FORM_FIELD_TYPE_DATETIME = "datetime"
+const FORM_FIELD_TYPE_DATE = "date"
+const FORM_FIELD_TYPE_TIME = "time"
+const FORM_FIELD_TYPE_DATERANGE = "daterange"
 const FORM_FIELD_TYPE_PASSWORD = "password"
 const FORM_FIELD_TYPE_RAW = "raw"
+const FORM_FIELD_TYPE_MULTISELECT = "multiselect"
+const FORM_FIELD_TYPE_CHECKBOXES = "checkboxes"
+const FORM_FIELD_TYPE_RADIO = "radio"
+const FORM_FIELD_TYPE_CHECKBOX = "checkbox"
+const FORM_FIELD_TYPE_SWITCH = "switch"
+const FORM_FIELD_TYPE_TAGS = "tags"
+const FORM_FIELD_TYPE_RELATION = "relation"
+const FORM_FIELD_TYPE_REPEATER = "repeater"
+const FORM_FIELD_TYPE_JSON = "json"
+const FORM_FIELD_TYPE_MARKDOWN = "markdown"
+const FORM_FIELD_TYPE_HIDDEN = "hidden"
+
+const FIELD_GROUP_TYPE_SECTION = "section"
+const FIELD_GROUP_TYPE_TAB = "tab"
+const FIELD_GROUP_TYPE_COLLAPSIBLE = "collapsible"
+
+const FORM_FIELD_WIDTH_FULL = "full"
+const FORM_FIELD_WIDTH_HALF = "half"
+const FORM_FIELD_WIDTH_THIRD = "third"
+const FORM_FIELD_WIDTH_TWO_THIRDS = "two_thirds"
+
+const CONDITION_OPERATOR_EQUALS = "eq"
+const CONDITION_OPERATOR_NOT_EQUALS = "neq"
+const CONDITION_OPERATOR_IN = "in"
+const CONDITION_OPERATOR_NOT_IN = "not_in"
+const CONDITION_OPERATOR_EMPTY = "empty"
+const CONDITION_OPERATOR_NOT_EMPTY = "not_empty"
+
+const FILTER_TYPE_TEXT = "text"
+const FILTER_TYPE_SELECT = "select"
+const FILTER_TYPE_BOOLEAN = "boolean"
+const FILTER_TYPE_DATE_RANGE = "date_range"
+const FILTER_TYPE_NUMBER_RANGE = "number_range"
+
+// The storage formats of the date fields, any Go time layout can be used as well
+const DATE_FORMAT_DATE = "2006-01-02"
+const DATE_FORMAT_DATETIME = "2006-01-02 15:04:05"
+const DATE_FORMAT_TIME = "15:04:05"
+const DATE_FORMAT_RFC3339 = "2006-01-02T15:04:05Z07:00"
+const DATE_FORMAT_UNIX = "unix"
+
+// The formats of the values of the columns and of the read fields,
+// shown in the locale and in the timezone of the user
+const FORMAT_NUMBER = "number"
+const FORMAT_CURRENCY = "currency"
+const FORMAT_PERCENT = "percent"
+const FORMAT_DATE = "date"
+const FORMAT_DATETIME = "datetime"
+const FORMAT_RELATIVE_TIME = "relative_time"
+
+const ADMIN_MENU_SIDEBAR = "sidebar"
+const ADMIN_MENU_TOPBAR = "topbar"
+
+const ROUTE_ENTITY_COLUMNS_SAVE_AJAX = pathEntityColumnsSaveAjax
+const ROUTE_ENTITY_CREATE_AJAX = pathEntityCreateAjax
+const ROUTE_ENTITY_EXPORT = pathEntityExport
+const ROUTE_ENTITY_FETCH_AJAX = pathEntityFetchAjax
+const ROUTE_ENTITY_INLINE_UPDATE_AJAX = pathEntityInlineUpdateAjax
+const ROUTE_ENTITY_MANAGER = pathEntityManager
+const ROUTE_ENTITY_MARKDOWN_PREVIEW_AJAX = pathEntityMarkdownPreviewAjax
+const ROUTE_ENTITY_OPTIONS_AJAX = pathEntityOptionsAjax
+const ROUTE_ENTITY_READ = pathEntityRead
+const ROUTE_ENTITY_SEARCH_AJAX = pathEntitySearchAjax
+const ROUTE_ENTITY_TRASH_AJAX = pathEntityTrashAjax
+const ROUTE_ENTITY_UPDATE = pathEntityUpdate
+const ROUTE_ENTITY_UPDATE_AJAX = pathEntityUpdateAjax
+const ROUTE_ENTITY_VIEW_DELETE_AJAX = pathEntityViewDeleteAjax
+const ROUTE_ENTITY_VIEW_SAVE_AJAX = pathEntityViewSaveAjax
+
+// The variants of the buttons of the themes
+const BUTTON_PRIMARY = "primary"
+const BUTTON_SECONDARY = "secondary"
+const BUTTON_SUCCESS = "success"
+const BUTTON_DANGER = "danger"
+const BUTTON_LINK = "link"
+const BUTTON_OUTLINE_PRIMARY = "outline-primary"
+const BUTTON_OUTLINE_SECONDARY = "outline-secondary"
+const BUTTON_OUTLINE_DANGER = "outline-danger"
+const BUTTON_OUTLINE_WARNING = "outline-warning"
+const BUTTON_OUTLINE_INFO = "outline-info"
+
+// The variants of the alerts of the themes
+const ALERT_DANGER = "danger"
+const ALERT_INFO = "info"
+const ALERT_SUCCESS = "success"
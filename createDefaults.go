@@ -0,0 +1,187 @@
+package crud
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// createValues returns the initial values of the create form, in the
+// format expected by the form. These are the values of the fields,
+// overridden by the ones returned by FuncCreateDefaults.
+//
+// Parameters:
+// - r: the HTTP request
+// - fields: the fields of the create form
+// - prefill: whether the values of the entity being duplicated and
+// the values in the query of the request, keyed by field name,
+// override the defaults of the editable fields
+//
+// Returns:
+// - map[string]string - the values, keyed by field name
+// - error - the error fetching the entity being duplicated, if any
+func (crud *Crud) createValues(r *http.Request, fields []FormField, prefill bool) (map[string]string, error) {
+	values := map[string]string{}
+
+	lo.ForEach(fields, func(field FormField, index int) {
+		values[field.Name] = field.Value
+	})
+
+	if crud.funcCreateDefaults != nil {
+		for name, value := range crud.funcCreateDefaults(r) {
+			values[name] = value
+		}
+	}
+
+	if !prefill {
+		return crud.displayDateValues(r, fields, values), nil
+	}
+
+	duplicateValues, err := crud.duplicateValues(r, fields)
+
+	if err != nil {
+		return crud.displayDateValues(r, fields, values), err
+	}
+
+	for name, value := range duplicateValues {
+		values[name] = value
+	}
+
+	values = crud.displayDateValues(r, fields, values)
+
+	query := r.URL.Query()
+
+	for _, field := range editableFields(r, fields) {
+		if !query.Has(field.Name) && !query.Has(field.Name+"[]") {
+			continue
+		}
+
+		values[field.Name] = requestValue(r, field)
+	}
+
+	return values, nil
+}
+
+// lockedCreateDefaults returns the values returned by FuncCreateDefaults
+// for the create fields the user cannot edit, e.g. the read-only owner.
+// These are saved in place of the posted values, which are not trusted.
+func (crud *Crud) lockedCreateDefaults(r *http.Request) map[string]string {
+	if crud.funcCreateDefaults == nil {
+		return map[string]string{}
+	}
+
+	editableNames := crud.listCreateNames(r)
+
+	lockedNames := lo.FilterMap(crud.createFields, func(field FormField, _ int) (string, bool) {
+		return field.Name, field.Name != "" && !lo.Contains(editableNames, field.Name)
+	})
+
+	return lo.PickByKeys(crud.funcCreateDefaults(r), lockedNames)
+}
+
+// duplicateValues returns the values of the entity being duplicated,
+// set by the duplicate query parameter, for the editable create fields.
+// The values are fetched by FuncFetchUpdateData and transformed by
+// FuncDuplicate, if set.
+func (crud *Crud) duplicateValues(r *http.Request, fields []FormField) (map[string]string, error) {
+	entityID := crud.duplicateID(r)
+
+	if entityID == "" {
+		return map[string]string{}, nil
+	}
+
+	_, data, err := crud.fetchDuplicate(entityID)
+
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	names := lo.Map(editableFields(r, fields), func(field FormField, _ int) string {
+		return field.Name
+	})
+
+	return lo.PickByKeys(data, names), nil
+}
+
+// duplicateID returns the ID of the entity being duplicated, set by the
+// duplicate query parameter, or an empty string if none
+func (crud *Crud) duplicateID(r *http.Request) string {
+	return lo.Ternary(crud.isDuplicateEnabled(), strings.TrimSpace(r.URL.Query().Get("duplicate")), "")
+}
+
+// fetchDuplicate fetches the values of the entity being duplicated.
+//
+// Returns:
+// - map[string]string - the values of the entity
+// - map[string]string - the values transformed by FuncDuplicate, if set
+// - error - the error of FuncFetchUpdateData, if any
+func (crud *Crud) fetchDuplicate(entityID string) (map[string]string, map[string]string, error) {
+	source, err := crud.funcFetchUpdateData(entityID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if crud.funcDuplicate == nil {
+		return source, source, nil
+	}
+
+	return source, crud.funcDuplicate(entityID, lo.Assign(source)), nil
+}
+
+// applyDuplicate applies FuncDuplicate to the posted values of a duplicate
+// being created. The posted values still equal to the ones of the entity
+// being duplicated, where FuncDuplicate changes them, are replaced by the
+// changed ones, e.g. a unique code cannot be posted back.
+//
+// Returns:
+// - string - the error message, or an empty string if applied
+func (crud *Crud) applyDuplicate(r *http.Request, posts map[string]string) string {
+	entityID := crud.duplicateID(r)
+
+	if entityID == "" || crud.funcDuplicate == nil {
+		return ""
+	}
+
+	source, duplicate, err := crud.fetchDuplicate(entityID)
+
+	if err != nil {
+		return crud.t("The entity to duplicate could not be fetched")
+	}
+
+	for name, value := range posts {
+		original, exists := source[name]
+
+		if exists && value == original && duplicate[name] != original {
+			posts[name] = duplicate[name]
+		}
+	}
+
+	return ""
+}
+
+// isDuplicateEnabled returns true if the entities can be duplicated,
+// which requires both FuncCreate and FuncFetchUpdateData
+func (crud *Crud) isDuplicateEnabled() bool {
+	return crud.funcCreate != nil && crud.funcFetchUpdateData != nil
+}
+
+// isCreateRequested returns true if the create form should be opened
+// when the entity manager is loaded, e.g. from a link of UrlEntityCreate
+func (crud *Crud) isCreateRequested(r *http.Request) bool {
+	return crud.funcCreate != nil && r.URL.Query().Get("create") == "1"
+}
+
+// encodeQuery encodes the values as URL query parameters,
+// sorted by name, e.g. "a=1&b=2"
+func encodeQuery(values map[string]string) string {
+	names := lo.Keys(values)
+	sort.Strings(names)
+
+	return lo.Reduce(names, func(query string, name string, index int) string {
+		return query + lo.Ternary(index == 0, "", "&") + url.QueryEscape(name) + "=" + url.QueryEscape(values[name])
+	}, "")
+}
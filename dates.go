@@ -0,0 +1,228 @@
+package crud
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// dateWireLayouts are the layouts of the values exchanged with the
+// date pickers of the forms, in the timezone of the user
+var dateWireLayouts = map[string]string{
+	FORM_FIELD_TYPE_DATE:      DATE_FORMAT_DATE,
+	FORM_FIELD_TYPE_DATERANGE: DATE_FORMAT_DATE,
+	FORM_FIELD_TYPE_DATETIME:  DATE_FORMAT_DATETIME,
+	FORM_FIELD_TYPE_TIME:      DATE_FORMAT_TIME,
+}
+
+// isDateField returns true if the field holds a date, a time,
+// a date and time or a date range
+func (field FormField) isDateField() bool {
+	_, isDate := dateWireLayouts[field.Type]
+	return isDate
+}
+
+// dateFormat returns the format the values of the field are stored in,
+// the DateFormat of the field or the wire layout of its type
+func (field FormField) dateFormat() string {
+	return lo.Ternary(field.DateFormat == "", dateWireLayouts[field.Type], field.DateFormat)
+}
+
+// location returns the timezone the dates are shown to the user in,
+// from FuncTimezone, Timezone or UTC
+func (crud *Crud) location(r *http.Request) *time.Location {
+	timezone := crud.timezone
+
+	if crud.funcTimezone != nil && r != nil {
+		timezone = lo.CoalesceOrEmpty(crud.funcTimezone(r), timezone)
+	}
+
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+
+	return location
+}
+
+// storeDateValues converts the posted values of the date fields from
+// the timezone of the user to the storage format of the fields, in place.
+//
+// Parameters:
+// - r: the HTTP request
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+//
+// Returns:
+// - string - the error message, or an empty string if all dates are valid
+func (crud *Crud) storeDateValues(r *http.Request, fields []FormField, posts map[string]string) string {
+	location := crud.location(r)
+
+	for _, field := range fields {
+		value, posted := posts[field.Name]
+		if !posted || !field.isDateField() {
+			continue
+		}
+
+		stored, err := storeDateValue(field, value, location)
+
+		if err != nil {
+			label := lo.Ternary(field.Label == "", field.Name, field.Label)
+			return crud.t("{label} is not valid: {error}", "label", crud.t(label), "error", err.Error())
+		}
+
+		posts[field.Name] = stored
+	}
+
+	return ""
+}
+
+// displayDateValues returns a copy of the values with the values of
+// the date fields converted from the storage format to the timezone
+// of the user, as expected by the date pickers
+func (crud *Crud) displayDateValues(r *http.Request, fields []FormField, values map[string]string) map[string]string {
+	location := crud.location(r)
+	converted := map[string]string{}
+
+	for name, value := range values {
+		converted[name] = value
+	}
+
+	for _, field := range fields {
+		value, exists := values[field.Name]
+		if !exists || !field.isDateField() {
+			continue
+		}
+
+		converted[field.Name] = displayDateValue(field, value, location)
+	}
+
+	return converted
+}
+
+// storeDateValue converts a posted date value to the storage format.
+// Dates with a time are converted to UTC, date ranges are stored as
+// a JSON array with the start and the end date.
+func storeDateValue(field FormField, value string, location *time.Location) (string, error) {
+	if field.Type == FORM_FIELD_TYPE_DATERANGE {
+		dates := lo.Compact(DecodeMultiValue(value))
+
+		if len(dates) == 0 {
+			return "", nil
+		}
+
+		if len(dates) != 2 {
+			return "", errors.New("a start and an end date are required")
+		}
+
+		start, errStart := time.Parse(DATE_FORMAT_DATE, dates[0])
+		end, errEnd := time.Parse(DATE_FORMAT_DATE, dates[1])
+
+		if errStart != nil || errEnd != nil {
+			return "", errors.New("the dates must be in the format YYYY-MM-DD")
+		}
+
+		if end.Before(start) {
+			return "", errors.New("the end date must not be before the start date")
+		}
+
+		return EncodeMultiValue([]string{formatStoredTime(start, field.dateFormat()), formatStoredTime(end, field.dateFormat())}), nil
+	}
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return "", nil
+	}
+
+	parsed, err := parseWireTime(field, value, location)
+
+	if err != nil {
+		return "", err
+	}
+
+	if field.Type == FORM_FIELD_TYPE_DATETIME {
+		parsed = parsed.UTC()
+	}
+
+	return formatStoredTime(parsed, field.dateFormat()), nil
+}
+
+// displayDateValue converts a stored date value to the wire layout,
+// returning the value as is if it cannot be parsed
+func displayDateValue(field FormField, value string, location *time.Location) string {
+	if field.Type == FORM_FIELD_TYPE_DATERANGE {
+		return EncodeMultiValue(lo.Map(DecodeMultiValue(value), func(date string, _ int) string {
+			parsed, err := parseStoredTime(date, field.dateFormat())
+			return lo.Ternary(err == nil, parsed.Format(DATE_FORMAT_DATE), date)
+		}))
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return value
+	}
+
+	parsed, err := parseStoredTime(strings.TrimSpace(value), field.dateFormat())
+
+	if err != nil {
+		return value
+	}
+
+	if field.Type == FORM_FIELD_TYPE_DATETIME {
+		parsed = parsed.In(location)
+	}
+
+	return parsed.Format(dateWireLayouts[field.Type])
+}
+
+// parseWireTime parses a value posted by the date pickers, also
+// accepting times without seconds. Only the dates with a time are
+// in the timezone of the user, the others are parsed in UTC.
+func parseWireTime(field FormField, value string, location *time.Location) (time.Time, error) {
+	layouts := []string{dateWireLayouts[field.Type]}
+	location = lo.Ternary(field.Type == FORM_FIELD_TYPE_DATETIME, location, time.UTC)
+
+	switch field.Type {
+	case FORM_FIELD_TYPE_DATETIME:
+		layouts = append(layouts, "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04")
+	case FORM_FIELD_TYPE_TIME:
+		layouts = append(layouts, "15:04")
+	}
+
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, errors.New("expected the format " + humanLayout(layouts[0]))
+}
+
+// parseStoredTime parses a stored value, in the storage format
+func parseStoredTime(value string, format string) (time.Time, error) {
+	if format == DATE_FORMAT_UNIX {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		return time.Unix(seconds, 0).UTC(), err
+	}
+
+	return time.ParseInLocation(format, value, time.UTC)
+}
+
+// formatStoredTime formats the time in the storage format
+func formatStoredTime(value time.Time, format string) string {
+	if format == DATE_FORMAT_UNIX {
+		return strconv.FormatInt(value.Unix(), 10)
+	}
+
+	return value.Format(format)
+}
+
+// humanLayout returns the layout as shown in the error messages
+func humanLayout(layout string) string {
+	return strings.NewReplacer("2006", "YYYY", "01", "MM", "02", "DD", "15", "HH", "04", "mm", "05", "ss").Replace(layout)
+}
@@ -0,0 +1,57 @@
+package crud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreAndDisplayDateValue(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("Timezone data not available: ", err.Error())
+	}
+
+	tests := []struct {
+		field   FormField
+		posted  string
+		stored  string
+		invalid bool
+	}{
+		{FormField{Type: FORM_FIELD_TYPE_DATETIME}, "2024-07-01 10:30:00", "2024-07-01 09:30:00", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATETIME, DateFormat: DATE_FORMAT_RFC3339}, "2024-01-15 10:30:00", "2024-01-15T10:30:00Z", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATETIME, DateFormat: DATE_FORMAT_UNIX}, "2024-07-01 10:30:00", "1719826200", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATE}, "2024-07-01", "2024-07-01", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATE, DateFormat: "02/01/2006"}, "2024-07-01", "01/07/2024", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATE, DateFormat: DATE_FORMAT_UNIX}, "2024-07-01", "1719792000", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATE, DateFormat: DATE_FORMAT_RFC3339}, "2024-07-01", "2024-07-01T00:00:00Z", false},
+		{FormField{Type: FORM_FIELD_TYPE_TIME}, "10:30:00", "10:30:00", false},
+		{FormField{Type: FORM_FIELD_TYPE_DATERANGE}, `["2024-07-01","2024-07-10"]`, `["2024-07-01","2024-07-10"]`, false},
+		{FormField{Type: FORM_FIELD_TYPE_DATERANGE}, `["2024-07-10","2024-07-01"]`, "", true},
+		{FormField{Type: FORM_FIELD_TYPE_DATE}, "01/07/2024", "", true},
+		{FormField{Type: FORM_FIELD_TYPE_DATETIME}, "", "", false},
+	}
+
+	for _, test := range tests {
+		stored, err := storeDateValue(test.field, test.posted, london)
+
+		if test.invalid {
+			if err == nil {
+				t.Error("Value "+test.posted+" MUST be invalid, but found: ", stored)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Error("Value "+test.posted+" MUST be valid, but found: ", err.Error())
+			continue
+		}
+
+		if stored != test.stored {
+			t.Error("Value "+test.posted+" MUST be stored as "+test.stored+", but found: ", stored)
+		}
+
+		if displayed := displayDateValue(test.field, stored, london); displayed != test.posted {
+			t.Error("Value "+stored+" MUST be displayed as "+test.posted+", but found: ", displayed)
+		}
+	}
+}
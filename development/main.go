@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/gouniverse/crud"
-	"github.com/gouniverse/hb"
 	"github.com/gouniverse/utils"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -18,10 +17,8 @@ import (
 
 var db *sql.DB
 
-func crudHandler(w http.ResponseWriter, r *http.Request) {
-	crudInstance, err := crud.NewCrud(crud.CrudConfig{
-		Endpoint:           "/crud",
-		HomeURL:            "/",
+func usersConfig() crud.CrudConfig {
+	return crud.CrudConfig{
 		FileManagerURL:     "/file-manager",
 		EntityNameSingular: "User",
 		EntityNamePlural:   "Users",
@@ -79,14 +76,7 @@ func crudHandler(w http.ResponseWriter, r *http.Request) {
 				"name": "Charles Dickens",
 			}, nil
 		},
-	})
-
-	if err != nil {
-		w.Write([]byte(err.Error()))
-		return
 	}
-
-	crudInstance.Handler(w, r)
 }
 
 func main() {
@@ -109,12 +99,28 @@ func main() {
 
 	log.Println("4. Starting server on http://" + utils.Env("SERVER_HOST") + ":" + utils.Env("SERVER_PORT") + " ...")
 	log.Println("URL: http://" + utils.Env("APP_URL") + " ...")
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		menu := hb.Raw("<a href='/crud'>Standalone CRUD</a>")
-		w.Write([]byte(menu.ToHTML()))
+	admin, err := crud.NewAdmin(crud.AdminConfig{
+		Endpoint: "/admin",
+		Title:    "Development",
+		Entities: []crud.AdminEntity{
+			{
+				Key:     "users",
+				Section: "Accounts",
+				Icon:    "bi-people",
+				Config:  usersConfig(),
+			},
+		},
 	})
-	mux.HandleFunc("/crud", crudHandler)
+
+	if err != nil {
+		log.Panic("Admin failed: " + err.Error())
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.RedirectHandler("/admin", http.StatusFound))
+	mux.HandleFunc("/admin", admin.Handler)
+	mux.HandleFunc("/admin/", admin.Handler)
 
 	srv := &http.Server{
 		Handler: mux,
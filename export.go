@@ -0,0 +1,85 @@
+package crud
+
+import (
+	"encoding/csv"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/icons"
+	"github.com/samber/lo"
+)
+
+// exportFileNameUnsafe matches the characters replaced in the name
+// of the export file
+var exportFileNameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// exportFormulaPrefixes are the first characters which make
+// a spreadsheet evaluate a cell as a formula
+const exportFormulaPrefixes = "=+-@\t\r"
+
+// exportButton generates the Export button of the entity manager
+// heading, exporting the rows of the query shown
+func (crud *Crud) exportButton(query RowsQuery) hb.TagInterface {
+	return hb.Hyperlink().
+		Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false) + " float-end me-2").
+		Href(appendQuery(crud.UrlEntityExport(), rowsQueryParams(query).Encode())).
+		Child(icons.Icon("bi-download", 16, 16, "#333").Style("margin-top:-4px;margin-right:8px;")).
+		Text(crud.t("Export"))
+}
+
+// pageEntityExport exports the rows of the query of the request as CSV,
+// the visible columns with the values as they are, not formatted
+func (crud *Crud) pageEntityExport(w http.ResponseWriter, r *http.Request) {
+	query, _ := crud.rowsQuery(r)
+	if len(query.Columns) == 0 {
+		query.Columns = crud.userColumns(r)
+	}
+
+	rows, _, err := crud.rows(query)
+
+	if err != nil {
+		http.Error(w, crud.t("There was an error retrieving the data. Please try again later"), http.StatusInternalServerError)
+		return
+	}
+
+	columns := crud.visibleColumns(query)
+
+	fileName := strings.Trim(exportFileNameUnsafe.ReplaceAllString(crud.entityNamePlural, "_"), "_")
+	fileName = lo.Ternary(fileName == "", "export", fileName) + ".csv"
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+
+	writer := csv.NewWriter(w)
+
+	writer.Write(lo.Map(columns, func(index int, _ int) string {
+		return exportCell(crud.t(crud.columns[index].label()))
+	}))
+
+	for _, row := range rows {
+		writer.Write(lo.Map(columns, func(index int, _ int) string {
+			cell, _ := lo.Nth(row.Data, index)
+			return exportCell(stripRawMarkers(cell))
+		}))
+	}
+
+	writer.Flush()
+}
+
+// exportCell returns the value of a CSV cell as is, prefixed with
+// a quote if a spreadsheet would evaluate it as a formula. The numbers,
+// such as -5, are left as they are.
+func exportCell(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+
+	if value != "" && strings.ContainsRune(exportFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,85 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// isVisibleFor returns true if the field is shown for the request,
+// as decided by VisibleF
+func (field FormField) isVisibleFor(r *http.Request) bool {
+	return field.VisibleF == nil || field.VisibleF(r)
+}
+
+// isEditableFor returns true if the value of the field is accepted
+// from the request. Hidden, read-only and disabled fields are not
+func (field FormField) isEditableFor(r *http.Request) bool {
+	if field.Readonly || field.Disabled || !field.isVisibleFor(r) {
+		return false
+	}
+
+	return field.EditableF == nil || field.EditableF(r)
+}
+
+// requestFields returns the fields shown for the request, with the
+// fields not editable for the request marked as read-only.
+//
+// Parameters:
+// - r: the HTTP request
+// - fields: the fields of the form
+//
+// Returns:
+// - []FormField - the fields to show
+func requestFields(r *http.Request, fields []FormField) []FormField {
+	visible := lo.Filter(fields, func(field FormField, _ int) bool {
+		return field.isVisibleFor(r)
+	})
+
+	return lo.Map(visible, func(field FormField, _ int) FormField {
+		if !field.Disabled && !field.isEditableFor(r) {
+			field.Readonly = true
+		}
+		return field
+	})
+}
+
+// editableFields returns the fields whose values are accepted from the request
+func editableFields(r *http.Request, fields []FormField) []FormField {
+	return lo.Filter(fields, func(field FormField, _ int) bool {
+		return field.Name != "" && field.isEditableFor(r)
+	})
+}
+
+// visibleValues returns a copy of the values without the values of the
+// fields hidden for the request, so that they are not sent to the browser
+func visibleValues(r *http.Request, fields []FormField, values map[string]string) map[string]string {
+	hidden := lo.FilterMap(fields, func(field FormField, _ int) (string, bool) {
+		return field.Name, !field.isVisibleFor(r)
+	})
+
+	return lo.OmitByKeys(values, hidden)
+}
+
+// lockedInput makes the input of a read-only or disabled field not
+// editable. Text inputs get the readonly attribute, so that the value
+// can still be selected and copied, the other inputs are disabled.
+func lockedInput(field FormField, input *hb.Tag) *hb.Tag {
+	if !field.Readonly && !field.Disabled {
+		return input
+	}
+
+	isText := input.TagName == "input" || input.TagName == "textarea"
+
+	if field.Readonly && isText {
+		return input.Attr("readonly", "readonly")
+	}
+
+	if isText || input.TagName == "select" || strings.HasPrefix(input.TagName, "el-") {
+		return input.Attr("disabled", "disabled")
+	}
+
+	return hb.NewTag("fieldset").Attr("disabled", "disabled").Child(input)
+}
@@ -0,0 +1,274 @@
+package crud
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// filterType returns the type of the filter, FILTER_TYPE_TEXT by default
+func (filter Filter) filterType() string {
+	return lo.Ternary(filter.Type == "", FILTER_TYPE_TEXT, filter.Type)
+}
+
+// isRange returns true if the filter has a start and an end value
+func (filter Filter) isRange() bool {
+	return filter.filterType() == FILTER_TYPE_DATE_RANGE || filter.filterType() == FILTER_TYPE_NUMBER_RANGE
+}
+
+// options returns the static options followed by the ones
+// returned by OptionsF, if set
+func (filter Filter) options() []FormFieldOption {
+	options := append([]FormFieldOption{}, filter.Options...)
+
+	if filter.OptionsF != nil {
+		options = append(options, filter.OptionsF()...)
+	}
+
+	return options
+}
+
+// filterParam returns the name of the URL query parameter of the filter,
+// with the suffix of the range filters, e.g. "filter_price_from"
+func filterParam(name string, suffix string) string {
+	return "filter_" + name + lo.Ternary(suffix == "", "", "_"+suffix)
+}
+
+// parseRowsQuery returns the query of the rows held by the URL query
+// parameters, the active filters, the sort and the visible columns.
+// Invalid values and unknown columns are ignored.
+//
+// Parameters:
+// - params: the URL query parameters
+//
+// Returns:
+// - RowsQuery - the query of the rows
+func (crud *Crud) parseRowsQuery(params url.Values) RowsQuery {
+	query := RowsQuery{Filters: []FilterValue{}, Columns: []string{}}
+	names := lo.Map(crud.columns, func(column Column, _ int) string {
+		return column.label()
+	})
+
+	if lo.Contains(names, params.Get("sort")) {
+		query.Sort = params.Get("sort")
+		query.SortDescending = params.Get("sort_dir") == "desc"
+	}
+
+	query.Columns = crud.knownColumns(params["columns"])
+
+	for _, filter := range crud.filters {
+		value := FilterValue{Name: filter.Name, Type: filter.filterType()}
+
+		switch value.Type {
+		case FILTER_TYPE_DATE_RANGE, FILTER_TYPE_NUMBER_RANGE:
+			isValid := lo.Ternary(value.Type == FILTER_TYPE_DATE_RANGE, isFilterDate, isFilterNumber)
+			value.From = strings.TrimSpace(params.Get(filterParam(filter.Name, "from")))
+			value.To = strings.TrimSpace(params.Get(filterParam(filter.Name, "to")))
+			value.From = lo.Ternary(isValid(value.From), value.From, "")
+			value.To = lo.Ternary(isValid(value.To), value.To, "")
+			if value.From == "" && value.To == "" {
+				continue
+			}
+		case FILTER_TYPE_BOOLEAN:
+			value.Value = params.Get(filterParam(filter.Name, ""))
+			if value.Value != "1" && value.Value != "0" {
+				continue
+			}
+		default:
+			value.Value = strings.TrimSpace(params.Get(filterParam(filter.Name, "")))
+			if value.Value == "" {
+				continue
+			}
+		}
+
+		query.Filters = append(query.Filters, value)
+	}
+
+	return query
+}
+
+// rowsQueryParams returns the URL query parameters holding the query
+// of the rows, the reverse of parseRowsQuery
+func rowsQueryParams(query RowsQuery) url.Values {
+	params := url.Values{}
+
+	for _, filter := range query.Filters {
+		if filter.Type == FILTER_TYPE_DATE_RANGE || filter.Type == FILTER_TYPE_NUMBER_RANGE {
+			if filter.From != "" {
+				params.Set(filterParam(filter.Name, "from"), filter.From)
+			}
+			if filter.To != "" {
+				params.Set(filterParam(filter.Name, "to"), filter.To)
+			}
+			continue
+		}
+
+		params.Set(filterParam(filter.Name, ""), filter.Value)
+	}
+
+	if query.Sort != "" {
+		params.Set("sort", query.Sort)
+		params.Set("sort_dir", lo.Ternary(query.SortDescending, "desc", "asc"))
+	}
+
+	for _, name := range query.Columns {
+		params.Add("columns", name)
+	}
+
+	return params
+}
+
+// isFilterDate returns true if the value is a date in the format YYYY-MM-DD
+func isFilterDate(value string) bool {
+	_, err := time.Parse(DATE_FORMAT_DATE, value)
+	return err == nil
+}
+
+// isFilterNumber returns true if the value is a number
+func isFilterNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// rows returns the rows of the entity manager table, from FuncRowsQuery
+// with the query if set, or else from FuncRows
+//
+// Parameters:
+// - query: the query of the rows
+//
+// Returns:
+// - []Row - the rows
+// - map[string]map[string]int - the counts of the rows per option of
+// the select filters, keyed by filter name and option key
+// - error - the error, if any
+func (crud *Crud) rows(query RowsQuery) ([]Row, map[string]map[string]int, error) {
+	if crud.funcRowsQuery != nil {
+		return crud.funcRowsQuery(query)
+	}
+
+	rows, err := crud.funcRows()
+
+	return rows, map[string]map[string]int{}, err
+}
+
+// filterBar generates the filter bar above the entity manager table,
+// a form submitting the filters as URL query parameters, keeping the
+// view, the sort and the visible columns
+func (crud *Crud) filterBar(query RowsQuery, viewName string, facets map[string]map[string]int) hb.TagInterface {
+	if len(crud.filters) == 0 {
+		return nil
+	}
+
+	managerURL, _ := url.Parse(crud.UrlEntityManager())
+
+	// the sort and the visible columns, without the filters
+	state := rowsQueryParams(RowsQuery{Sort: query.Sort, SortDescending: query.SortDescending, Columns: query.Columns})
+
+	form := hb.Form().
+		Method("GET").
+		Action(managerURL.Path).
+		Class("card card-body bg-light mt-3 py-2")
+
+	// the query of the endpoint is replaced by the one of the form
+	params := managerURL.Query()
+	params.Set("view", viewName)
+	for name, values := range state {
+		params[name] = values
+	}
+	names := lo.Keys(params)
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range params[name] {
+			form.Child(hb.Input().Type(hb.TYPE_HIDDEN).Name(name).Value(value))
+		}
+	}
+
+	inputs := lo.Map(crud.filters, func(filter Filter, _ int) hb.TagInterface {
+		value, _ := query.Filter(filter.Name)
+		label := crud.t(lo.Ternary(filter.Label == "", filter.Name, filter.Label))
+
+		return hb.Div().
+			Class(lo.Ternary(filter.isRange(), "col-md-4", "col-md-2")).
+			Child(hb.Label().Class("form-label small mb-0").Text(label)).
+			Child(crud.filterInput(filter, value, facets[filter.Name]))
+	})
+
+	buttons := hb.Div().
+		Class("col-md-auto d-flex align-items-end").
+		Child(hb.Button().
+			Type(hb.TYPE_SUBMIT).
+			Class(crud.theme.ButtonClass(BUTTON_PRIMARY, true)).
+			Text(crud.t("Filter"))).
+		ChildIf(len(query.Filters) > 0, hb.Hyperlink().
+			Class(crud.theme.ButtonClass(BUTTON_LINK, true)).
+			Href(appendQuery(crud.UrlEntityManager(), "view="+lo.Ternary(len(state) == 0, "", "&"+state.Encode()))).
+			Text(crud.t("Clear")))
+
+	return form.Child(hb.Div().
+		Class("row g-2").
+		Children(inputs).
+		Child(buttons))
+}
+
+// filterInput generates the input of the filter, with the counts of
+// the rows in the options of the select filters
+func (crud *Crud) filterInput(filter Filter, value FilterValue, counts map[string]int) hb.TagInterface {
+	name := filterParam(filter.Name, "")
+
+	switch filter.filterType() {
+	case FILTER_TYPE_SELECT:
+		return hb.Select().
+			Class(crud.theme.InputClass(FORM_FIELD_TYPE_SELECT) + " form-select-sm").
+			Name(name).
+			Child(hb.Option().Value("").Text(crud.t("All"))).
+			Children(lo.Map(filter.options(), func(option FormFieldOption, _ int) hb.TagInterface {
+				text := crud.t(option.Value)
+				if count, exists := counts[option.Key]; exists {
+					text += " (" + strconv.Itoa(count) + ")"
+				}
+				return hb.Option().
+					Value(option.Key).
+					AttrIf(option.Key == value.Value, "selected", "selected").
+					Text(text)
+			}))
+	case FILTER_TYPE_BOOLEAN:
+		return hb.Select().
+			Class(crud.theme.InputClass(FORM_FIELD_TYPE_SELECT) + " form-select-sm").
+			Name(name).
+			Children(lo.Map([]FormFieldOption{{Key: "", Value: "Any"}, {Key: "1", Value: "Yes"}, {Key: "0", Value: "No"}}, func(option FormFieldOption, _ int) hb.TagInterface {
+				return hb.Option().
+					Value(option.Key).
+					AttrIf(option.Key == value.Value, "selected", "selected").
+					Text(crud.t(option.Value))
+			}))
+	case FILTER_TYPE_DATE_RANGE, FILTER_TYPE_NUMBER_RANGE:
+		isNumber := filter.filterType() == FILTER_TYPE_NUMBER_RANGE
+		rangeInput := func(suffix string, value string, placeholder string) hb.TagInterface {
+			return hb.Input().
+				Type(lo.Ternary(isNumber, hb.TYPE_NUMBER, hb.TYPE_DATE)).
+				Class(crud.theme.InputClass(FORM_FIELD_TYPE_STRING)).
+				Name(filterParam(filter.Name, suffix)).
+				Value(value).
+				Attr("placeholder", placeholder).
+				AttrIf(isNumber, "step", "any")
+		}
+		return hb.Div().
+			Class("input-group input-group-sm").
+			Child(rangeInput("from", value.From, crud.t("From"))).
+			Child(hb.Span().Class("input-group-text").Text("–")).
+			Child(rangeInput("to", value.To, crud.t("To")))
+	}
+
+	return hb.Input().
+		Type(hb.TYPE_TEXT).
+		Class(crud.theme.InputClass(FORM_FIELD_TYPE_STRING)+" form-control-sm").
+		Name(name).
+		Value(value.Value).
+		Attr("placeholder", crud.t("Contains..."))
+}
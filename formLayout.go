@@ -0,0 +1,196 @@
+package crud
+
+import (
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// formLayout lays out the form groups of the fields in a grid,
+// grouped in sections, tabs or collapsible panels as configured
+// by the FieldGroups.
+//
+// Parameters:
+// - fields: the fields of the form
+//
+// Returns:
+// - []hb.TagInterface - the laid out form
+func (crud *Crud) formLayout(fields []FormField) []hb.TagInterface {
+	tags := []hb.TagInterface{}
+	groups := groupFields(fields)
+
+	for index := 0; index < len(groups); index++ {
+		group := groups[index]
+
+		if group.name == "" {
+			tags = append(tags, crud.formRow(group.fields))
+			continue
+		}
+
+		config := crud.fieldGroup(group.name)
+
+		if config.Type == FIELD_GROUP_TYPE_TAB {
+			tabs := []fieldGroup{group}
+			for index+1 < len(groups) && groups[index+1].name != "" && crud.fieldGroup(groups[index+1].name).Type == FIELD_GROUP_TYPE_TAB {
+				index++
+				tabs = append(tabs, groups[index])
+			}
+			tags = append(tags, crud.formTabs(tabs))
+			continue
+		}
+
+		if config.Type == FIELD_GROUP_TYPE_COLLAPSIBLE {
+			tags = append(tags, crud.formCollapsible(config, group.fields))
+			continue
+		}
+
+		tags = append(tags, crud.formSection(config, group.fields))
+	}
+
+	return tags
+}
+
+// formRow generates a grid row with the form groups of the fields
+func (crud *Crud) formRow(fields []FormField) hb.TagInterface {
+	return hb.Div().Class("row").Children(crud.formFields(fields))
+}
+
+// formSection generates a fieldset with a heading
+func (crud *Crud) formSection(config FieldGroup, fields []FormField) hb.TagInterface {
+	return hb.NewTag("fieldset").
+		Class("mt-4").
+		Child(hb.NewTag("legend").Class("fs-5 border-bottom pb-1 mb-0").Text(config.Label)).
+		ChildIf(config.Help != "", hb.Paragraph().Class("text-muted mb-0").HTML(config.Help)).
+		Child(crud.formRow(fields))
+}
+
+// formCollapsible generates a collapsible panel
+func (crud *Crud) formCollapsible(config FieldGroup, fields []FormField) hb.TagInterface {
+	collapseID := "collapse_" + utils.StrRandomFromGamma(16, "abcdefghijklmnopqrstuvwxyz1234567890")
+
+	header := hb.Div().
+		Class("card-header").
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_LINK, false)+" text-decoration-none p-0").
+			ClassIf(config.Collapsed, "collapsed").
+			Attr("data-bs-toggle", "collapse").
+			Attr("data-bs-target", "#"+collapseID).
+			Attr("aria-expanded", lo.Ternary(config.Collapsed, "false", "true")).
+			Attr("aria-controls", collapseID).
+			Text(config.Label))
+
+	body := hb.Div().
+		ID(collapseID).
+		Class("collapse").
+		ClassIf(!config.Collapsed, "show").
+		Child(hb.Div().
+			Class("card-body pt-0").
+			ChildIf(config.Help != "", hb.Paragraph().Class("text-muted mt-3 mb-0").HTML(config.Help)).
+			Child(crud.formRow(fields)))
+
+	return hb.Div().
+		Class("card mt-3").
+		Child(header).
+		Child(body)
+}
+
+// formTabs generates a set of tabs, one per group
+func (crud *Crud) formTabs(groups []fieldGroup) hb.TagInterface {
+	tabsID := "tabs_" + utils.StrRandomFromGamma(16, "abcdefghijklmnopqrstuvwxyz1234567890")
+
+	nav := hb.UL().Class("nav nav-tabs mt-3").Attr("role", "tablist")
+	panes := hb.Div().Class("tab-content border border-top-0 rounded-bottom px-3 pb-3")
+
+	for index, group := range groups {
+		config := crud.fieldGroup(group.name)
+		paneID := tabsID + "_" + utils.ToString(index)
+
+		nav.Child(hb.LI().
+			Class("nav-item").
+			Attr("role", "presentation").
+			Child(hb.Button().
+				Type(hb.TYPE_BUTTON).
+				Class("nav-link").
+				ClassIf(index == 0, "active").
+				Attr("data-bs-toggle", "tab").
+				Attr("data-bs-target", "#"+paneID).
+				Attr("role", "tab").
+				Text(config.Label)))
+
+		panes.Child(hb.Div().
+			ID(paneID).
+			Class("tab-pane fade").
+			ClassIf(index == 0, "show active").
+			Attr("role", "tabpanel").
+			ChildIf(config.Help != "", hb.Paragraph().Class("text-muted mt-3 mb-0").HTML(config.Help)).
+			Child(crud.formRow(group.fields)))
+	}
+
+	return hb.Div().ID(tabsID).Child(nav).Child(panes)
+}
+
+// fieldGroup returns the configuration of the group with the
+// specified name, or a section with the name as label if the
+// group is not configured
+func (crud *Crud) fieldGroup(name string) FieldGroup {
+	config, found := lo.Find(crud.fieldGroups, func(group FieldGroup) bool {
+		return group.Name == name
+	})
+
+	if !found {
+		config = FieldGroup{Name: name}
+	}
+
+	config.Label = crud.t(lo.Ternary(config.Label == "", config.Name, config.Label))
+
+	if config.Type == "" {
+		config.Type = FIELD_GROUP_TYPE_SECTION
+	}
+
+	return config
+}
+
+// fieldColumnClass returns the grid column classes for the width of the field
+func fieldColumnClass(field FormField) string {
+	switch field.Width {
+	case FORM_FIELD_WIDTH_HALF:
+		return "col-12 col-md-6"
+	case FORM_FIELD_WIDTH_THIRD:
+		return "col-12 col-md-4"
+	case FORM_FIELD_WIDTH_TWO_THIRDS:
+		return "col-12 col-md-8"
+	}
+
+	return "col-12"
+}
+
+// isWideForm returns true if the form needs more room than the
+// default modal width, because of groups or multi-column fields
+func isWideForm(fields []FormField) bool {
+	return lo.SomeBy(fields, func(field FormField) bool {
+		return field.Group != "" || field.isRepeater() || (field.Width != "" && field.Width != FORM_FIELD_WIDTH_FULL)
+	})
+}
+
+// fieldGroup is a group of consecutive fields with the same Group
+type fieldGroup struct {
+	name   string
+	fields []FormField
+}
+
+// groupFields splits the fields into groups of consecutive fields
+// with the same Group
+func groupFields(fields []FormField) []fieldGroup {
+	groups := []fieldGroup{}
+
+	for _, field := range fields {
+		if len(groups) == 0 || groups[len(groups)-1].name != field.Group {
+			groups = append(groups, fieldGroup{name: field.Group})
+		}
+
+		groups[len(groups)-1].fields = append(groups[len(groups)-1].fields, field)
+	}
+
+	return groups
+}
@@ -0,0 +1,154 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// requestValue reads the posted value of a field.
+//
+// Multi-valued fields accept either a JSON array (as posted by the
+// Vue forms) or repeated keys (name=a&name=b or name[]=a&name[]=b),
+// and are always returned encoded as a JSON array. Boolean fields
+// are returned as "1" or "0". Repeater fields accept a JSON array of
+// objects, and only the values of the nested fields are kept.
+//
+// Parameters:
+// - r: the HTTP request
+// - field: the field to read
+//
+// Returns:
+// - string - the value of the field
+func requestValue(r *http.Request, field FormField) string {
+	if field.isRepeater() {
+		return repeaterRequestValue(field, utils.Req(r, field.Name, ""))
+	}
+
+	if field.isMultiValued() {
+		values := utils.ReqArray(r, field.Name+"[]", []string{})
+
+		if len(values) == 0 {
+			values = utils.ReqArray(r, field.Name, []string{})
+		}
+
+		if len(values) == 1 {
+			values = DecodeMultiValue(values[0])
+		}
+
+		if field.Type == FORM_FIELD_TYPE_TAGS {
+			values = splitTags(values)
+		}
+
+		return EncodeMultiValue(values)
+	}
+
+	if field.isBoolean() {
+		// a hidden "0" input may precede the checkbox, the last value wins
+		values := utils.ReqArray(r, field.Name, []string{})
+		value := ""
+		if len(values) > 0 {
+			value = values[len(values)-1]
+		}
+
+		return lo.Ternary(isTruthy(value), "1", "0")
+	}
+
+	return utils.Req(r, field.Name, "")
+}
+
+// splitTags splits comma separated tags, trims them and removes
+// the empty and duplicate ones
+func splitTags(values []string) []string {
+	tags := []string{}
+
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || lo.Contains(tags, tag) {
+				continue
+			}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// isTruthy returns true for the values posted by checked checkboxes
+func isTruthy(value string) bool {
+	return lo.Contains([]string{"1", "true", "on", "yes"}, strings.ToLower(strings.TrimSpace(value)))
+}
+
+// fieldByName returns the field with the specified name
+func fieldByName(fields []FormField, name string) (FormField, bool) {
+	return lo.Find(fields, func(field FormField) bool {
+		return field.Name == name
+	})
+}
+
+// formModel converts the string values of the fields to the values
+// expected by the Vue form model: the multi-valued fields become
+// arrays and repeater fields arrays of objects.
+//
+// Parameters:
+// - fields: the fields of the form
+// - values: the values, keyed by field name
+//
+// Returns:
+// - map[string]any - the form model
+func formModel(fields []FormField, values map[string]string) map[string]any {
+	model := map[string]any{}
+
+	for name, value := range values {
+		model[name] = value
+	}
+
+	for _, field := range fields {
+		if field.Name == "" {
+			continue
+		}
+
+		if field.isMultiValued() {
+			model[field.Name] = DecodeMultiValue(values[field.Name])
+		}
+
+		if field.Type == FORM_FIELD_TYPE_DATERANGE {
+			model[field.Name] = lo.Ternary(values[field.Name] == "", nil, DecodeMultiValue(values[field.Name]))
+		}
+
+		if field.isRepeater() {
+			model[field.Name] = DecodeRepeaterValue(values[field.Name])
+		}
+
+		if field.Type == FORM_FIELD_TYPE_JSON {
+			model[field.Name] = prettyJSON(values[field.Name])
+		}
+	}
+
+	return model
+}
+
+// isEmptyValue returns true if the value of the field is considered
+// not filled in, used for checking the required fields
+func isEmptyValue(field FormField, value string) bool {
+	if field.isMultiValued() {
+		return len(DecodeMultiValue(value)) == 0
+	}
+
+	if field.isBoolean() {
+		return value != "1"
+	}
+
+	if field.isRepeater() {
+		return len(DecodeRepeaterValue(value)) == 0
+	}
+
+	if field.Type == FORM_FIELD_TYPE_DATERANGE {
+		return len(lo.Compact(DecodeMultiValue(value))) == 0
+	}
+
+	return lo.IsEmpty(value)
+}
@@ -0,0 +1,288 @@
+package crud
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// localeFormat holds the separators of the numbers and the layouts of
+// the dates of a locale
+type localeFormat struct {
+	decimal  string
+	group    string
+	percent  string
+	date     string
+	dateTime string
+
+	// currencySuffix shows the currency after the amount
+	currencySuffix bool
+}
+
+// localeFormats are the formats of the supported locales, keyed by
+// locale or language, "en" being the default
+var localeFormats = map[string]localeFormat{
+	"en":    {".", ",", "%", "02 Jan 2006", "02 Jan 2006, 15:04", false},
+	"en-us": {".", ",", "%", "Jan 2, 2006", "Jan 2, 2006, 3:04 PM", false},
+	"bg":    {",", "\u00a0", "\u00a0%", "02.01.2006", "02.01.2006, 15:04", true},
+	"de":    {",", ".", "\u00a0%", "02.01.2006", "02.01.2006, 15:04", true},
+	"es":    {",", ".", "\u00a0%", "02/01/2006", "02/01/2006, 15:04", true},
+	"fr":    {",", "\u202f", "\u00a0%", "02/01/2006", "02/01/2006 15:04", true},
+	"it":    {",", ".", "%", "02/01/2006", "02/01/2006, 15:04", true},
+	"ja":    {".", ",", "%", "2006/01/02", "2006/01/02 15:04", false},
+	"nl":    {",", ".", "%", "02-01-2006", "02-01-2006 15:04", false},
+	"pl":    {",", "\u00a0", "%", "02.01.2006", "02.01.2006, 15:04", true},
+	"pt":    {",", "\u00a0", "%", "02/01/2006", "02/01/2006, 15:04", true},
+	"pt-br": {",", ".", "%", "02/01/2006", "02/01/2006, 15:04", false},
+	"ru":    {",", "\u00a0", "\u00a0%", "02.01.2006", "02.01.2006, 15:04", true},
+	"sv":    {",", "\u00a0", "\u00a0%", "2006-01-02", "2006-01-02 15:04", true},
+	"zh":    {".", ",", "%", "2006/01/02", "2006/01/02 15:04", false},
+}
+
+// currencySymbols are the symbols shown in place of the codes of the
+// common currencies
+var currencySymbols = map[string]string{
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"USD": "$",
+}
+
+// currencyDecimals are the decimals of the currencies without cents,
+// the other currencies having 2
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// timeValueLayouts are the layouts tried when parsing the values
+// of the dates for display
+var timeValueLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// localeFormat returns the format of the locale of the request handled
+// by the Crud, of its language, or else of English
+func (crud *Crud) localeFormat() localeFormat {
+	locale := normalizeLocale(crud.locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	if format, found := localeFormats[locale]; found {
+		return format
+	}
+
+	if format, found := localeFormats[language]; found {
+		return format
+	}
+
+	return localeFormats["en"]
+}
+
+// formatValue formats the value in the locale of the request, the dates
+// being stored in UTC and shown in the timezone of the user.
+//
+// Parameters:
+// - format: one of the FORMAT_* constants
+// - currency: the currency code of the FORMAT_CURRENCY values
+// - value: the value, as stored
+// - location: the timezone of the user
+//
+// Returns:
+// - string - the formatted value, or the value as is if it cannot be parsed
+func (crud *Crud) formatValue(format string, currency string, value string, location *time.Location) string {
+	if strings.TrimSpace(value) == "" {
+		return value
+	}
+
+	switch format {
+	case FORMAT_NUMBER, FORMAT_CURRENCY, FORMAT_PERCENT:
+		return crud.formatNumberValue(format, currency, value)
+	case FORMAT_DATE, FORMAT_DATETIME, FORMAT_RELATIVE_TIME:
+		parsed, hasTime, err := parseTimeValue(value, time.UTC)
+
+		if err != nil {
+			return value
+		}
+
+		if hasTime {
+			parsed = parsed.In(location)
+		}
+
+		return crud.formatTime(format, parsed, hasTime)
+	}
+
+	return value
+}
+
+// formatDateValue formats the value of a date field, already in the
+// timezone of the user, with FORMAT_DATETIME by default
+func (crud *Crud) formatDateValue(format string, value string, location *time.Location) string {
+	parsed, hasTime, err := parseTimeValue(value, location)
+
+	if err != nil {
+		return value
+	}
+
+	return crud.formatTime(lo.Ternary(format == "", FORMAT_DATETIME, format), parsed, hasTime)
+}
+
+// formatNumberValue formats a number, a currency amount or a percentage,
+// the percentages being stored as fractions, e.g. 0.25 for 25%
+func (crud *Crud) formatNumberValue(format string, currency string, value string) string {
+	value = strings.TrimSpace(value)
+	number, err := strconv.ParseFloat(value, 64)
+
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return value
+	}
+
+	localeFormat := crud.localeFormat()
+
+	// the decimals of the value, as many as needed if in exponent form
+	decimals := -1
+	if !strings.ContainsAny(value, "eE") {
+		_, fraction, _ := strings.Cut(value, ".")
+		decimals = len(fraction)
+	}
+
+	switch format {
+	case FORMAT_PERCENT:
+		return formatNumber(number*100, lo.Ternary(decimals < 0, -1, max(decimals-2, 0)), localeFormat) + localeFormat.percent
+	case FORMAT_CURRENCY:
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		decimals, found := currencyDecimals[currency]
+		amount := formatNumber(number, lo.Ternary(found, decimals, 2), localeFormat)
+		symbol, hasSymbol := currencySymbols[currency]
+		sign := ""
+
+		if strings.HasPrefix(amount, "-") {
+			sign, amount = "-", amount[1:]
+		}
+
+		if currency == "" {
+			return sign + amount
+		}
+
+		if localeFormat.currencySuffix {
+			return sign + amount + "\u00a0" + lo.Ternary(hasSymbol, symbol, currency)
+		}
+
+		return sign + lo.Ternary(hasSymbol, symbol, currency+"\u00a0") + amount
+	}
+
+	return formatNumber(number, decimals, localeFormat)
+}
+
+// formatNumber formats the number with the separators of the locale,
+// with the decimals, or as many as needed if negative
+func formatNumber(number float64, decimals int, localeFormat localeFormat) string {
+	formatted := strconv.FormatFloat(math.Abs(number), 'f', decimals, 64)
+	integer, fraction, hasFraction := strings.Cut(formatted, ".")
+
+	groups := []string{}
+	for len(integer) > 3 {
+		groups = append([]string{integer[len(integer)-3:]}, groups...)
+		integer = integer[:len(integer)-3]
+	}
+	groups = append([]string{integer}, groups...)
+
+	formatted = strings.Join(groups, localeFormat.group)
+
+	if hasFraction {
+		formatted += localeFormat.decimal + fraction
+	}
+
+	isZero := strings.Trim(formatted, "0"+localeFormat.group+localeFormat.decimal) == ""
+
+	return lo.Ternary(number < 0 && !isZero, "-", "") + formatted
+}
+
+// formatTime formats the time in the locale of the request, the dates
+// without a time being shown without one
+func (crud *Crud) formatTime(format string, value time.Time, hasTime bool) string {
+	localeFormat := crud.localeFormat()
+
+	switch format {
+	case FORMAT_RELATIVE_TIME:
+		return crud.relativeTime(value, time.Now())
+	case FORMAT_DATETIME:
+		if hasTime {
+			return value.Format(localeFormat.dateTime)
+		}
+	}
+
+	return value.Format(localeFormat.date)
+}
+
+// relativeTime returns the time relative to now, e.g. "3 days ago"
+// or "in 2 hours", translated in the locale of the request
+func (crud *Crud) relativeTime(value time.Time, now time.Time) string {
+	difference := now.Sub(value)
+	isPast := difference >= 0
+
+	if difference < 0 {
+		difference = -difference
+	}
+
+	units := []struct {
+		duration time.Duration
+		singular string
+		plural   string
+	}{
+		{365 * 24 * time.Hour, "year", "years"},
+		{30 * 24 * time.Hour, "month", "months"},
+		{24 * time.Hour, "day", "days"},
+		{time.Hour, "hour", "hours"},
+		{time.Minute, "minute", "minutes"},
+	}
+
+	for _, unit := range units {
+		count := int(difference / unit.duration)
+
+		if count < 1 {
+			continue
+		}
+
+		name := lo.Ternary(count == 1, unit.singular, unit.plural)
+		key := lo.Ternary(isPast, "{count} "+name+" ago", "in {count} "+name)
+
+		return crud.t(key, "count", strconv.Itoa(count))
+	}
+
+	return crud.t("just now")
+}
+
+// parseTimeValue parses the value of a date, in one of the layouts of
+// the dates or as a Unix timestamp, in the location.
+//
+// Returns:
+// - time.Time - the time
+// - bool - true if the value has a time, not only a date
+// - error - the error, if the value cannot be parsed
+func parseTimeValue(value string, location *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).In(location), true, nil
+	}
+
+	var err error
+
+	for _, layout := range timeValueLayouts {
+		var parsed time.Time
+
+		if parsed, err = time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, layout != DATE_FORMAT_DATE, nil
+		}
+	}
+
+	return time.Time{}, false, err
+}
@@ -0,0 +1,65 @@
+package crud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatValue(t *testing.T) {
+	sofia, err := time.LoadLocation("Europe/Sofia")
+	if err != nil {
+		t.Skip("Timezone data not available: ", err.Error())
+	}
+
+	tests := []struct {
+		locale    string
+		format    string
+		currency  string
+		value     string
+		formatted string
+	}{
+		{"en", FORMAT_NUMBER, "", "1234567.891", "1,234,567.891"},
+		{"de-AT", FORMAT_NUMBER, "", "-1234.5", "-1.234,5"},
+		{"fr", FORMAT_NUMBER, "", "1234", "1\u202f234"},
+		{"en", FORMAT_CURRENCY, "usd", "-1234.5", "-$1,234.50"},
+		{"de", FORMAT_CURRENCY, "EUR", "1234.5", "1.234,50\u00a0€"},
+		{"en", FORMAT_CURRENCY, "CHF", "99", "CHF\u00a099.00"},
+		{"en", FORMAT_CURRENCY, "JPY", "1500", "¥1,500"},
+		{"en", FORMAT_PERCENT, "", "0.125", "12.5%"},
+		{"de", FORMAT_PERCENT, "", "0.5", "50\u00a0%"},
+		{"en", FORMAT_DATE, "", "2024-07-01", "01 Jul 2024"},
+		{"de", FORMAT_DATETIME, "", "2024-07-01 09:30:00", "01.07.2024, 12:30"},
+		{"en-US", FORMAT_DATETIME, "", "2024-07-01T21:30:00Z", "Jul 2, 2024, 12:30 AM"},
+		{"en", FORMAT_DATETIME, "", "1719826200", "01 Jul 2024, 12:30"},
+		{"en", FORMAT_NUMBER, "", "n/a", "n/a"},
+		{"en", FORMAT_DATE, "", "someday", "someday"},
+		{"en", "", "", "1234", "1234"},
+	}
+
+	for _, test := range tests {
+		crud := Crud{locale: test.locale}
+
+		if formatted := crud.formatValue(test.format, test.currency, test.value, sofia); formatted != test.formatted {
+			t.Error("Value "+test.value+" MUST be formatted as "+test.formatted+" in "+test.locale+", but found: ", formatted)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	crud := Crud{}
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[time.Duration]string{
+		-30 * time.Second:    "just now",
+		-time.Minute:         "1 minute ago",
+		-3 * 24 * time.Hour:  "3 days ago",
+		2 * time.Hour:        "in 2 hours",
+		400 * 24 * time.Hour: "in 1 year",
+	}
+
+	for difference, expected := range tests {
+		if relative := crud.relativeTime(now.Add(difference), now); relative != expected {
+			t.Error("Relative time MUST be "+expected+", but found: ", relative)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// normalizeLocale returns the locale in lower case with dashes,
+// e.g. "de-at" for "de_AT"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// replacePlaceholders replaces the {name} placeholders of the message
+// with the params
+func replacePlaceholders(message string, params map[string]string) string {
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+
+	return message
+}
+
+// requestLocale returns the locale of the request, from FuncLocale,
+// or else the first language of the Accept-Language header
+func requestLocale(funcLocale func(r *http.Request) string, r *http.Request) string {
+	if funcLocale != nil {
+		return funcLocale(r)
+	}
+
+	first, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	locale, _, _ := strings.Cut(first, ";")
+
+	return normalizeLocale(locale)
+}
+
+// t translates the key in the locale of the request handled by the Crud
+//
+// Parameters:
+// - key: the English string, with {name} placeholders
+// - params: the names and values of the placeholders, in pairs
+//
+// Returns:
+// - string - the translated string
+func (crud *Crud) t(key string, params ...string) string {
+	values := map[string]string{}
+
+	for index := 0; index+1 < len(params); index += 2 {
+		values[params[index]] = params[index+1]
+	}
+
+	if crud.translator == nil {
+		return replacePlaceholders(key, values)
+	}
+
+	return crud.translator.Translate(crud.locale, key, values)
+}
+
+// scriptMessages are the keys of the messages of the scripts,
+// translated with crudT
+var scriptMessages = []string{
+	"Are you sure you want to move this entity to trash bin?",
+	"At least one column must be visible",
+	"Default view",
+	"Delete",
+	`Delete view "{name}"?`,
+	"Entity saved",
+	"Entity trashed",
+	"Move to trash bin",
+	"Name",
+	"Name is required",
+	"Oops...",
+	"Save",
+	"Save view",
+}
+
+// scriptTranslations returns the script defining crudT, which
+// translates the messages of the scripts in the locale of the request,
+// e.g. crudT('Delete view "{name}"?', {name: name})
+func (crud *Crud) scriptTranslations() string {
+	messages := map[string]string{}
+
+	for _, key := range scriptMessages {
+		messages[key] = crud.t(key)
+	}
+
+	messagesJSON, _ := utils.ToJSON(messages)
+
+	return `
+const crudMessages = ` + messagesJSON + `;
+const crudT = (key, params = {}) => Object.keys(params).reduce((message, name) => message.split('{' + name + '}').join(params[name]), crudMessages[key] || key);
+`
+}
+
+// tEntity translates the key with the {name} placeholder replaced by
+// the translated singular name of the entity, e.g. "New {name}"
+func (crud *Crud) tEntity(key string) string {
+	return crud.t(key, "name", crud.t(crud.entityNameSingular))
+}
+
+// tJS returns the translation of the key as a JavaScript string literal
+func (crud *Crud) tJS(key string, params ...string) string {
+	literal, _ := utils.ToJSON(crud.t(key, params...))
+	return literal
+}
+
+// translateOptions returns the options with their values translated
+func (crud *Crud) translateOptions(options []FormFieldOption) []FormFieldOption {
+	return lo.Map(options, func(option FormFieldOption, _ int) FormFieldOption {
+		option.Value = crud.t(option.Value)
+		return option
+	})
+}
+
+// translateField returns the field with its label, help and options
+// translated to be shown in the form. The nested fields are translated
+// by the repeater.
+func (crud *Crud) translateField(field FormField) FormField {
+	field.Label = crud.t(lo.Ternary(field.Label == "", field.Name, field.Label))
+	field.Help = lo.Ternary(field.Help == "", "", crud.t(field.Help))
+	field.Options = crud.translateOptions(field.options())
+	field.OptionsF = nil
+
+	return field
+}
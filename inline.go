@@ -0,0 +1,209 @@
+package crud
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/api"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// inlineFieldTypes are the types of the fields which can be edited
+// inline, in the cells of the entity manager table
+var inlineFieldTypes = []string{
+	"",
+	FORM_FIELD_TYPE_STRING,
+	FORM_FIELD_TYPE_NUMBER,
+	FORM_FIELD_TYPE_TEXTAREA,
+	FORM_FIELD_TYPE_SELECT,
+	FORM_FIELD_TYPE_RADIO,
+	FORM_FIELD_TYPE_CHECKBOX,
+	FORM_FIELD_TYPE_SWITCH,
+	FORM_FIELD_TYPE_DATE,
+	FORM_FIELD_TYPE_DATETIME,
+	FORM_FIELD_TYPE_TIME,
+}
+
+// checkInlineColumns checks the inline editable columns refer to update
+// fields of a type which can be edited inline
+func checkInlineColumns(columns []Column, updateFields []FormField) error {
+	for _, column := range columns {
+		if column.Field == "" {
+			continue
+		}
+
+		field, found := fieldByName(updateFields, column.Field)
+
+		if !found {
+			return errors.New("Field " + column.Field + " of column " + column.Name + " is not an update field")
+		}
+
+		if !lo.Contains(inlineFieldTypes, field.Type) {
+			return errors.New("Field " + column.Field + " of column " + column.Name + " cannot be edited inline")
+		}
+	}
+
+	return nil
+}
+
+// inlineField returns the update field edited inline in the column,
+// if it is editable for the request
+func (crud *Crud) inlineField(r *http.Request, column Column) (FormField, bool) {
+	if column.Field == "" || column.Relation != nil || !crud.isUpdateEnabled() {
+		return FormField{}, false
+	}
+
+	field, found := fieldByName(crud.updateFields, column.Field)
+
+	return field, found && field.isEditableFor(r)
+}
+
+// inlineCell generates the cell of an inline editable column, showing
+// the input of the field in place of the value when clicked
+func (crud *Crud) inlineCell(field FormField, rowID string, cell string, isRaw bool) hb.TagInterface {
+	key, _ := utils.ToJSON(rowID + ":" + field.Name)
+	key = strings.ReplaceAll(key, `"`, `'`)
+	entityID, _ := utils.ToJSON(rowID)
+	entityID = strings.ReplaceAll(entityID, `"`, `'`)
+	isEditing := "inline.key === " + key
+
+	return hb.TD().
+		Style("cursor:pointer;").
+		Attr("title", crud.t("Click to edit")).
+		Attr("v-on:click", "inlineEdit("+entityID+", '"+field.Name+"', '"+field.Type+"')").
+		Child(hb.Div().
+			Class("input-group input-group-sm").
+			Style("min-width:150px;").
+			Attr("v-if", isEditing).
+			Attr("v-on:click.stop", "").
+			Child(crud.inlineInput(field)).
+			Child(hb.Button().
+				Type(hb.TYPE_BUTTON).
+				Class(crud.theme.ButtonClass(BUTTON_SUCCESS, false)).
+				Attr("title", crud.t("Save")).
+				Attr("v-bind:disabled", "inline.saving").
+				Attr("v-on:click", "inlineSave").
+				Text("✓")).
+			Child(hb.Button().
+				Type(hb.TYPE_BUTTON).
+				Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)).
+				Attr("title", crud.t("Cancel")).
+				Attr("v-on:click", "inlineCancel").
+				Text("×"))).
+		Child(hb.Span().
+			Attr("v-else-if", key+" in inline.display").
+			Text("{{ inline.display[" + key + "] }}")).
+		Child(hb.Span().
+			Attr("v-else", "").
+			TextIf(!isRaw, cell).
+			HTMLIf(isRaw, cell))
+}
+
+// inlineInput generates the input of the field edited inline
+func (crud *Crud) inlineInput(field FormField) hb.TagInterface {
+	input := hb.Input().
+		Type(hb.TYPE_TEXT).
+		Class(crud.theme.InputClass(field.Type)).
+		Attr("v-model", "inline.value").
+		Attr("v-on:keydown.enter.prevent", "inlineSave").
+		Attr("v-on:keydown.esc", "inlineCancel")
+
+	switch field.Type {
+	case FORM_FIELD_TYPE_NUMBER:
+		input.Type(hb.TYPE_NUMBER)
+	case FORM_FIELD_TYPE_DATE:
+		input.Type(hb.TYPE_DATE)
+	case FORM_FIELD_TYPE_DATETIME:
+		input.Type(hb.TYPE_DATETIME).Attr("step", "1")
+	case FORM_FIELD_TYPE_TIME:
+		input.Type(hb.TYPE_TIME).Attr("step", "1")
+	case FORM_FIELD_TYPE_TEXTAREA:
+		input = hb.TextArea().
+			Class(crud.theme.InputClass(field.Type)).
+			Attr("rows", "2").
+			Attr("v-model", "inline.value").
+			Attr("v-on:keydown.esc", "inlineCancel")
+	case FORM_FIELD_TYPE_SELECT, FORM_FIELD_TYPE_RADIO:
+		input = hb.Select().
+			Class(crud.theme.InputClass(field.Type)).
+			Attr("v-model", "inline.value").
+			Attr("v-on:keydown.esc", "inlineCancel").
+			Children(lo.Map(field.options(), func(option FormFieldOption, _ int) hb.TagInterface {
+				return hb.Option().Value(option.Key).Text(crud.t(option.Value))
+			}))
+	case FORM_FIELD_TYPE_CHECKBOX, FORM_FIELD_TYPE_SWITCH:
+		input = hb.Div().
+			Class("input-group-text").
+			Child(hb.Input().
+				Type(hb.TYPE_CHECKBOX).
+				Class("form-check-input mt-0").
+				Attr("true-value", "1").
+				Attr("false-value", "0").
+				Attr("v-model", "inline.value"))
+	}
+
+	return input
+}
+
+// inlineDisplayValue returns the value of the field as shown in the
+// cell after an inline edit, the labels of the selected options, the
+// value in the format of the column or in the timezone of the user
+func (crud *Crud) inlineDisplayValue(r *http.Request, column Column, field FormField, value string) string {
+	if field.isBoolean() {
+		return crud.t(lo.Ternary(value == "1", "Yes", "No"))
+	}
+
+	if options := crud.translateOptions(field.options()); len(options) > 0 {
+		option, found := lo.Find(options, func(option FormFieldOption) bool {
+			return option.Key == value
+		})
+		return lo.Ternary(found, option.Value, value)
+	}
+
+	if column.Format != "" {
+		return crud.formatValue(column.Format, column.Currency, value, crud.location(r))
+	}
+
+	if field.isDateField() {
+		return displayDateValue(field, value, crud.location(r))
+	}
+
+	return value
+}
+
+// pageEntityInlineUpdateAjax saves the value of a field edited inline
+// in the entity manager table, as a partial update of the entity
+func (crud *Crud) pageEntityInlineUpdateAjax(w http.ResponseWriter, r *http.Request) {
+	entityID := strings.TrimSpace(utils.Req(r, "entity_id", ""))
+
+	if entityID == "" {
+		api.Respond(w, r, api.Error(crud.t("Entity ID is required")))
+		return
+	}
+
+	column, found := lo.Find(crud.columns, func(column Column) bool {
+		return column.Field != "" && column.Field == utils.Req(r, "field", "")
+	})
+
+	field, editable := crud.inlineField(r, column)
+
+	if !found || !editable {
+		api.Respond(w, r, api.Error(crud.t("Field cannot be edited inline")))
+		return
+	}
+
+	posts := map[string]string{field.Name: requestValue(r, field)}
+
+	if errorMessage := crud.updateEntity(r, entityID, []FormField{field}, posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("Saved successfully"), map[string]interface{}{
+		"entity_id": entityID,
+		"display":   crud.inlineDisplayValue(r, column, field, posts[field.Name]),
+	}))
+}
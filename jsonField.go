@@ -0,0 +1,48 @@
+package crud
+
+import (
+	"github.com/gouniverse/hb"
+)
+
+// jsonEditorStyle is the style shared by the highlighted code and the
+// textarea laid over it, so that the text of both lines up
+const jsonEditorStyle = "font-family:monospace;font-size:0.875rem;line-height:1.5;padding:0.375rem 0.75rem;margin:0;white-space:pre-wrap;word-wrap:break-word;min-height:12rem;"
+
+// jsonEditor generates the editor of a JSON field, a textarea laid over
+// the syntax-highlighted code, with a button for pretty-printing the
+// JSON and the syntax error shown as the value is typed
+func (crud *Crud) jsonEditor(field FormField) hb.TagInterface {
+	model := "entityModel." + field.Name
+
+	highlighted := hb.NewTag("pre").
+		Class("border rounded bg-light").
+		Style(jsonEditorStyle+"position:absolute;inset:0;overflow:hidden;pointer-events:none;").
+		Attr("aria-hidden", "true").
+		Attr("v-html", "jsonHighlight("+model+")")
+
+	textarea := hb.TextArea().
+		Class(crud.theme.InputClass(field.Type)).
+		Style(jsonEditorStyle+"position:relative;background:transparent;color:transparent;caret-color:#212529;resize:vertical;").
+		Attr("spellcheck", "false").
+		Attr("v-model", model).
+		Attr("v-on:scroll", "$event.target.previousElementSibling.scrollTop = $event.target.scrollTop")
+
+	toolbar := hb.Div().
+		Class("d-flex justify-content-between align-items-center mt-1").
+		Child(hb.Span().
+			Class("small").
+			Attr("v-bind:class", "jsonError("+model+") ? 'text-danger' : 'text-success'").
+			Text("{{ jsonError(" + model + ") || " + crud.tJS("Valid JSON") + " }}")).
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, true)).
+			Attr("v-on:click", "jsonFormat('"+field.Name+"')").
+			Text(crud.t("Format")))
+
+	return hb.Div().
+		Child(hb.Div().
+			Class("position-relative").
+			Child(highlighted).
+			Child(textarea)).
+		Child(toolbar)
+}
@@ -0,0 +1,154 @@
+package crud
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// jsonSchemaPrinter prints the messages of the validation errors
+var jsonSchemaPrinter = message.NewPrinter(language.English)
+
+// compileJSONSchemas compiles the JSON Schemas of the fields and their
+// nested fields, keyed by the schema, the formats being asserted.
+// The schemas without $schema are compiled as JSON Schema 2020-12.
+func compileJSONSchemas(fields []FormField, schemas map[string]*jsonschema.Schema) error {
+	for _, field := range fields {
+		if field.JSONSchema != "" && schemas[field.JSONSchema] == nil {
+			schema, err := compileJSONSchema(field.JSONSchema)
+
+			if err != nil {
+				return errors.New("JSONSchema of field " + field.Name + " is invalid: " + err.Error())
+			}
+
+			schemas[field.JSONSchema] = schema
+		}
+
+		if err := compileJSONSchemas(field.Fields, schemas); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compileJSONSchema compiles a JSON Schema
+func compileJSONSchema(schemaJSON string) (*jsonschema.Schema, error) {
+	document, err := jsonschema.UnmarshalJSON(strings.NewReader(schemaJSON))
+
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+
+	if err := compiler.AddResource("schema.json", document); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile("schema.json")
+}
+
+// validateJSON validates the JSON document against the schema.
+//
+// Parameters:
+// - document: the JSON document
+// - schema: the compiled JSON Schema, the document is only checked
+// to be valid JSON if nil
+//
+// Returns:
+// - []string - the validation errors, each prefixed with the path of
+// the invalid value, e.g. "$.port: got number, want integer"
+func validateJSON(document string, schema *jsonschema.Schema) []string {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var value any
+
+	if err := decoder.Decode(&value); err != nil {
+		return []string{"invalid JSON: " + err.Error()}
+	}
+
+	if decoder.More() {
+		return []string{"invalid JSON: unexpected data after the value"}
+	}
+
+	if schema == nil {
+		return []string{}
+	}
+
+	err := schema.Validate(value)
+	validationError := &jsonschema.ValidationError{}
+
+	if errors.As(err, &validationError) {
+		return jsonSchemaErrors(validationError, value)
+	}
+
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	return []string{}
+}
+
+// jsonSchemaErrors returns the messages of the causes of the
+// validation error, prefixed with the path of the invalid value
+func jsonSchemaErrors(validationError *jsonschema.ValidationError, value any) []string {
+	if len(validationError.Causes) == 0 {
+		text := validationError.ErrorKind.LocalizedString(jsonSchemaPrinter)
+		return []string{jsonPath(value, validationError.InstanceLocation) + ": " + text}
+	}
+
+	errs := []string{}
+
+	for _, cause := range validationError.Causes {
+		errs = append(errs, jsonSchemaErrors(cause, value)...)
+	}
+
+	return errs
+}
+
+// jsonPath returns the path of the value at the location in
+// the document, e.g. "$.tags[1]"
+func jsonPath(document any, location []string) string {
+	path := "$"
+
+	for _, token := range location {
+		switch typed := document.(type) {
+		case []any:
+			index, _ := strconv.Atoi(token)
+			path += "[" + token + "]"
+			document, _ = lo.Nth(typed, index)
+		case map[string]any:
+			path += "." + token
+			document = typed[token]
+		}
+	}
+
+	return path
+}
+
+// prettyJSON indents the JSON document with two spaces,
+// returning it as is if it is not valid JSON
+func prettyJSON(document string) string {
+	if strings.TrimSpace(document) == "" {
+		return document
+	}
+
+	indented := bytes.Buffer{}
+
+	if err := json.Indent(&indented, []byte(document), "", "  "); err != nil {
+		return document
+	}
+
+	return indented.String()
+}
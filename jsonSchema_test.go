@@ -0,0 +1,83 @@
+package crud
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJSON(t *testing.T) {
+	schema, err := compileJSONSchema(`{
+		"type": "object",
+		"required": ["host", "port"],
+		"additionalProperties": false,
+		"minProperties": 2,
+		"properties": {
+			"host": {"type": "string", "minLength": 1},
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"mode": {"enum": ["dev", "prod"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+			"admin": {"$ref": "#/$defs/email"},
+			"ssl": {"type": "boolean"},
+			"certificate": {"type": "string"}
+		},
+		"patternProperties": {"^x-": {"type": "string"}},
+		"if": {"properties": {"ssl": {"const": true}}, "required": ["ssl"]},
+		"then": {"required": ["certificate"]},
+		"$defs": {"email": {"type": "string", "format": "email"}}
+	}`)
+
+	if err != nil {
+		t.Fatal("Error MUST be nil, but found: ", err.Error())
+	}
+
+	tests := map[string]string{
+		`{"host": "localhost", "port": 8080}`:                               ``,
+		`{"host": "localhost", "port": 8080, "mode": "prod"}`:               ``,
+		`{"host": "localhost", "port": 8080, "x-id": "a"}`:                  ``,
+		`{"host": "localhost", "port": 8080,}`:                              `invalid JSON`,
+		`{"host": "localhost"}`:                                             `$: missing property 'port'`,
+		`{"host": "localhost", "port": 80.5}`:                               `$.port: got number, want integer`,
+		`{"host": "localhost", "port": 70000}`:                              `$.port: maximum: got 70,000, want 65,535`,
+		`{"host": "", "port": 80}`:                                          `$.host: minLength: got 0, want 1`,
+		`{"host": "localhost", "port": 80, "mode": "test"}`:                 `$.mode: value must be one of 'dev', 'prod'`,
+		`{"host": "localhost", "port": 80, "debug": true}`:                  `$: additional properties 'debug' not allowed`,
+		`{"host": "localhost", "port": 80, "tags": ["a", 1]}`:               `$.tags[1]: got number, want string`,
+		`{"host": "localhost", "port": 80, "tags": ["a", "a"]}`:             `$.tags: items at 0 and 1 are equal`,
+		`{"host": "localhost", "port": 80, "x-id": 1}`:                      `$.x-id: got number, want string`,
+		`{"host": "localhost", "port": 80, "admin": "root"}`:                `$.admin: 'root' is not valid email`,
+		`{"host": "localhost", "port": 80, "ssl": true}`:                    `$: missing property 'certificate'`,
+		`["localhost", 80]`:                                                 `$: got array, want object`,
+		`{"host": "localhost", "port": 80, "ssl": true, "certificate": ""}`: ``,
+	}
+
+	for document, expected := range tests {
+		errs := strings.Join(validateJSON(document, schema), "; ")
+
+		if expected == "" && errs != "" {
+			t.Error("Document "+document+" MUST be valid, but found: ", errs)
+		}
+
+		if !strings.Contains(errs, expected) {
+			t.Error("Errors of "+document+" MUST contain "+expected+", but found: ", errs)
+		}
+	}
+
+	if errs := validateJSON(`{"any": [1, 2]}`, nil); len(errs) > 0 {
+		t.Error("Document without schema MUST be valid, but found: ", errs)
+	}
+}
+
+func TestErrorThrownWhenJSONSchemaInvalid(t *testing.T) {
+	for _, schema := range []string{`{"type": "objekt"}`, `{"$ref": "#/$defs/missing"}`, `{"pattern": "(["}`, `{"type": "object",}`} {
+		_, err := NewCrud(CrudConfig{
+			UpdateFields: []FormField{{Type: FORM_FIELD_TYPE_JSON, Name: "settings", JSONSchema: schema}},
+			FuncRows: func() ([]Row, error) {
+				return []Row{}, nil
+			},
+		})
+
+		if err == nil || !strings.Contains(err.Error(), "JSONSchema of field settings is invalid") {
+			t.Error("Schema "+schema+" MUST be invalid, but found: ", err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package crud
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdownInlinePatterns are the inline Markdown elements, applied in
+// order to the escaped text. Code spans, links and images are replaced
+// beforehand, so that their content and their URLs are left as is.
+var markdownInlinePatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*`), `<strong>$1</strong>`},
+	{regexp.MustCompile(`__(\S(?:.*?\S)?)__`), `<strong>$1</strong>`},
+	{regexp.MustCompile(`\*(\S(?:.*?\S)?)\*`), `<em>$1</em>`},
+	{regexp.MustCompile(`\b_(\S(?:.*?\S)?)_\b`), `<em>$1</em>`},
+	{regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`), `<del>$1</del>`},
+	{regexp.MustCompile(` {2,}\n`), "<br>\n"},
+}
+
+var markdownCodeSpan = regexp.MustCompile("`([^`]+)`")
+var markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+var markdownRule = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+var markdownUnorderedItem = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+var markdownOrderedItem = regexp.MustCompile(`^\s{0,3}\d+[.)]\s+(.*)$`)
+
+// renderMarkdown renders the Markdown to sanitized HTML. It supports
+// headings, paragraphs, emphasis, strikethrough, code spans and fenced
+// code blocks, block quotes, lists, horizontal rules, links and images.
+// Raw HTML in the Markdown is escaped.
+//
+// Parameters:
+// - markdown: the Markdown to render
+//
+// Returns:
+// - string - the sanitized HTML
+func renderMarkdown(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	return sanitizeHTML(renderMarkdownBlocks(lines))
+}
+
+// renderMarkdownBlocks renders the block elements of the lines
+func renderMarkdownBlocks(lines []string) string {
+	output := strings.Builder{}
+	paragraph := []string{}
+
+	flushParagraph := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		output.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+		paragraph = []string{}
+	}
+
+	for index := 0; index < len(lines); index++ {
+		line := lines[index]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			code := []string{}
+			for index+1 < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[index+1]), "```") {
+				index++
+				code = append(code, lines[index])
+			}
+			index++ // the closing fence
+			output.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case markdownHeading.MatchString(trimmed):
+			flushParagraph()
+			matches := markdownHeading.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(matches[1])))
+			output.WriteString("<h" + level + ">" + renderMarkdownInline(matches[2]) + "</h" + level + ">\n")
+		case markdownRule.MatchString(line):
+			flushParagraph()
+			output.WriteString("<hr>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			quote := []string{}
+			for ; index < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[index]), ">"); index++ {
+				quoted := strings.TrimPrefix(strings.TrimSpace(lines[index]), ">")
+				quote = append(quote, strings.TrimPrefix(quoted, " "))
+			}
+			index--
+			output.WriteString("<blockquote>\n" + renderMarkdownBlocks(quote) + "</blockquote>\n")
+		case markdownUnorderedItem.MatchString(line), markdownOrderedItem.MatchString(line):
+			flushParagraph()
+			pattern := markdownUnorderedItem
+			tag := "ul"
+			if !markdownUnorderedItem.MatchString(line) {
+				pattern = markdownOrderedItem
+				tag = "ol"
+			}
+			output.WriteString("<" + tag + ">\n")
+			for ; index < len(lines) && pattern.MatchString(lines[index]); index++ {
+				item := pattern.FindStringSubmatch(lines[index])[1]
+				output.WriteString("<li>" + renderMarkdownInline(item) + "</li>\n")
+			}
+			index--
+			output.WriteString("</" + tag + ">\n")
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+
+	flushParagraph()
+
+	return output.String()
+}
+
+// renderMarkdownInline renders the inline elements of the text
+func renderMarkdownInline(text string) string {
+	codeSpans := []string{}
+
+	text = markdownCodeSpan.ReplaceAllStringFunc(text, func(match string) string {
+		codeSpans = append(codeSpans, "<code>"+html.EscapeString(match[1:len(match)-1])+"</code>")
+		return "\x00" + string(rune(len(codeSpans)-1+'0')) + "\x00"
+	})
+
+	text, links := markdownLinks(html.EscapeString(text))
+	text = markdownEmphasis(text)
+
+	for index, link := range links {
+		text = strings.ReplaceAll(text, "\x01"+strconv.Itoa(index)+"\x01", link)
+	}
+
+	for index, codeSpan := range codeSpans {
+		text = strings.ReplaceAll(text, "\x00"+string(rune(index+'0'))+"\x00", codeSpan)
+	}
+
+	return text
+}
+
+// markdownEmphasis renders the emphasis, the strikethrough and the line
+// breaks of the escaped text
+func markdownEmphasis(text string) string {
+	for _, inline := range markdownInlinePatterns {
+		text = inline.pattern.ReplaceAllString(text, inline.replacement)
+	}
+
+	return text
+}
+
+// markdownLinks replaces the links and the images of the escaped text
+// with placeholders, the URLs of which may hold balanced parentheses.
+//
+// Returns:
+// - string - the text with the placeholders
+// - []string - the HTML of the links and the images, by placeholder
+func markdownLinks(text string) (string, []string) {
+	links := []string{}
+	output := strings.Builder{}
+
+	for index := 0; index < len(text); {
+		isImage := strings.HasPrefix(text[index:], "![")
+
+		if text[index] != '[' && !isImage {
+			output.WriteByte(text[index])
+			index++
+			continue
+		}
+
+		labelStart := index + 1
+		if isImage {
+			labelStart++
+		}
+
+		labelLength := strings.IndexByte(text[labelStart:], ']')
+		destinationStart := labelStart + labelLength + 2
+
+		if labelLength < 0 || destinationStart > len(text) || text[destinationStart-1] != '(' || (labelLength == 0 && !isImage) {
+			output.WriteByte(text[index])
+			index++
+			continue
+		}
+
+		destination, end, found := markdownDestination(text, destinationStart)
+
+		if !found {
+			output.WriteByte(text[index])
+			index++
+			continue
+		}
+
+		label := text[labelStart : labelStart+labelLength]
+
+		if isImage {
+			links = append(links, `<img src="`+destination+`" alt="`+label+`">`)
+		} else {
+			links = append(links, `<a href="`+destination+`" target="_blank">`+markdownEmphasis(label)+`</a>`)
+		}
+
+		output.WriteString("\x01" + strconv.Itoa(len(links)-1) + "\x01")
+		index = end
+	}
+
+	return output.String(), links
+}
+
+// markdownDestination scans the URL of a link starting at the index,
+// up to the closing parenthesis not matching an opening one
+//
+// Returns:
+// - string - the URL
+// - int - the index after the closing parenthesis
+// - bool - true if the URL is closed and has no spaces
+func markdownDestination(text string, start int) (string, int, bool) {
+	depth := 0
+
+	for index := start; index < len(text); index++ {
+		switch text[index] {
+		case ' ', '\t', '\n':
+			return "", 0, false
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return text[start:index], index + 1, index > start
+			}
+			depth--
+		}
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,111 @@
+package crud
+
+import (
+	"net/http"
+
+	"github.com/gouniverse/api"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+)
+
+// markdownToolbarButtons are the buttons of the toolbar of the Markdown
+// editor, each wrapping the selected text with a prefix and a suffix
+var markdownToolbarButtons = []struct {
+	title  string
+	text   string
+	before string
+	after  string
+}{
+	{"Heading", "H", "## ", ""},
+	{"Bold", "B", "**", "**"},
+	{"Italic", "I", "*", "*"},
+	{"Strikethrough", "S", "~~", "~~"},
+	{"Link", "🔗", "[", "](https://)"},
+	{"Image", "🖼", "![", "](https://)"},
+	{"Code", "</>", "`", "`"},
+	{"Quote", "❝", "> ", ""},
+	{"List", "•", "- ", ""},
+	{"Numbered list", "1.", "1. ", ""},
+}
+
+// markdownEditor generates the editor of a Markdown field, a textarea
+// with a toolbar and a preview tab rendered on the server
+func (crud *Crud) markdownEditor(field FormField) hb.TagInterface {
+	model := "entityModel." + field.Name
+	state := "tmp.markdown_" + field.Name
+	previewURL, _ := utils.ToJSON(crud.UrlEntityMarkdownPreviewAjax())
+
+	tabs := hb.UL().
+		Class("nav nav-tabs").
+		Child(hb.LI().Class("nav-item").Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class("nav-link").
+			Attr("v-bind:class", "{active: "+state+".tab !== 'preview'}").
+			Attr("v-on:click", state+".tab = 'write'").
+			Text(crud.t("Write")))).
+		Child(hb.LI().Class("nav-item").Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class("nav-link").
+			Attr("v-bind:class", "{active: "+state+".tab === 'preview'}").
+			Attr("v-on:click", "markdownPreview('"+field.Name+"', "+previewURL+")").
+			Text(crud.t("Preview"))))
+
+	toolbar := hb.Div().Class("btn-group btn-group-sm mt-2 mb-1")
+	for _, button := range markdownToolbarButtons {
+		before, _ := utils.ToJSON(button.before)
+		after, _ := utils.ToJSON(button.after)
+		toolbar.Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)).
+			Attr("title", crud.t(button.title)).
+			Attr("v-on:click", "markdownWrap('"+field.Name+"', $event, "+before+", "+after+")").
+			Text(button.text))
+	}
+
+	write := hb.Div().
+		Attr("v-show", state+".tab !== 'preview'").
+		Child(toolbar).
+		Child(hb.TextArea().
+			Class(crud.theme.InputClass(field.Type)+" font-monospace").
+			Attr("rows", "10").
+			Attr("v-model", model))
+
+	preview := hb.Div().
+		Class("border rounded p-3 mt-2").
+		Style("min-height:12rem;").
+		Attr("v-show", state+".tab === 'preview'").
+		Attr("v-html", state+".html")
+
+	return hb.Div().
+		Child(tabs).
+		Child(write).
+		Child(preview)
+}
+
+// markdownState returns the initial state of the Markdown editors
+func markdownState(fields []FormField) map[string]any {
+	state := map[string]any{}
+
+	for _, field := range fields {
+		if field.Type != FORM_FIELD_TYPE_MARKDOWN {
+			continue
+		}
+
+		state["markdown_"+field.Name] = map[string]any{
+			"tab":  "write",
+			"html": "",
+		}
+	}
+
+	return state
+}
+
+// pageEntityMarkdownPreviewAjax renders the posted Markdown to
+// sanitized HTML, used by the preview of the Markdown editors
+func (crud *Crud) pageEntityMarkdownPreviewAjax(w http.ResponseWriter, r *http.Request) {
+	markdown := utils.Req(r, "markdown", "")
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("Preview rendered successfully"), map[string]interface{}{
+		"html": renderMarkdown(markdown),
+	}))
+}
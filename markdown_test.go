@@ -0,0 +1,31 @@
+package crud
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"# Title":                     "<h1>Title</h1>\n",
+		"Hello **bold** and *italic*": "<p>Hello <strong>bold</strong> and <em>italic</em></p>\n",
+		"Use `<b>` tags":              "<p>Use <code>&lt;b&gt;</code> tags</p>\n",
+		"- one\n- two":                "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n",
+		"1. one\n2. two":              "<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n",
+		"> quoted":                    "<blockquote>\n<p>quoted</p>\n</blockquote>\n",
+		"```\nif a < b {\n}\n```":     "<pre><code>if a &lt; b {\n}</code></pre>\n",
+		"---":                         "<hr>\n",
+		"[Link](https://example.com)": `<p><a href="https://example.com" target="_blank" rel="noopener noreferrer">Link</a></p>` + "\n",
+		"[Link](javascript:alert(1))": `<p><a target="_blank" rel="noopener noreferrer">Link</a></p>` + "\n",
+		"[Go](https://en.wikipedia.org/wiki/Go_(programming_language)) rocks": `<p><a href="https://en.wikipedia.org/wiki/Go_(programming_language)" target="_blank" rel="noopener noreferrer">Go</a> rocks</p>` + "\n",
+		"[**Docs**](https://example.com/__init__*a*) and _more_":              `<p><a href="https://example.com/__init__*a*" target="_blank" rel="noopener noreferrer"><strong>Docs</strong></a> and <em>more</em></p>` + "\n",
+		"![A *b*](/img_(1)_x.png)":                                            `<p><img src="/img_(1)_x.png" alt="A *b*"></p>` + "\n",
+		"[not a link] (https://example.com)":                                  "<p>[not a link] (https://example.com)</p>\n",
+		"<script>alert(1)</script>":                                           "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		"![Logo](/logo.png)":                                                  `<p><img src="/logo.png" alt="Logo"></p>` + "\n",
+		"first line\nsecond line\n\nnext":                                     "<p>first line\nsecond line</p>\n<p>next</p>\n",
+	}
+
+	for markdown, expected := range tests {
+		if rendered := renderMarkdown(markdown); rendered != expected {
+			t.Errorf("Markdown %q MUST render as %q, but found: %q", markdown, expected, rendered)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package crud
+
+import (
+	"net/http"
+
+	"github.com/samber/lo"
+)
+
+// isPosted returns true if the request holds a value for the field,
+// telling an absent field apart from an empty one
+func isPosted(r *http.Request, field FormField) bool {
+	r.FormValue(field.Name) // parses the form
+
+	_, posted := r.Form[field.Name]
+	_, postedArray := r.Form[field.Name+"[]"]
+
+	return posted || postedArray
+}
+
+// isUpdateEnabled returns true if the entities can be updated
+func (crud *Crud) isUpdateEnabled() bool {
+	return crud.funcFetchUpdateData != nil && (crud.funcUpdate != nil || crud.funcUpdateChanges != nil)
+}
+
+// updateEntity saves the posted values of the entity. The values of the
+// fields which were not posted are kept from FuncFetchUpdateData, so
+// that a partial update does not clear them.
+//
+// Parameters:
+// - r: the HTTP request
+// - entityID: the ID of the entity
+// - fields: the fields to validate
+// - posts: the posted values, keyed by field name, converted in place
+// to the storage format
+//
+// Returns:
+// - string - the error message, or an empty string if saved successfully
+func (crud *Crud) updateEntity(r *http.Request, entityID string, fields []FormField, posts map[string]string) string {
+	before := map[string]string{}
+
+	if crud.funcFetchUpdateData != nil {
+		data, err := crud.funcFetchUpdateData(entityID)
+
+		if err != nil {
+			return crud.t("Fetch data failed")
+		}
+
+		before = data
+	}
+
+	// The values as shown to the user, like on create, with the fields
+	// which were not posted, e.g. the locked and the read-only ones,
+	// so that the conditions and the computed fields can depend on them
+	values := lo.Assign(crud.displayDateValues(r, crud.updateFields, before), posts)
+
+	// Set the computed values, as these may depend on the posted ones
+	computeValues(r, crud.updateFields, values)
+
+	// Validate the fields, skipping the hidden ones
+	if errorMessage := crud.validateFields(fields, values); errorMessage != "" {
+		return errorMessage
+	}
+
+	computedNames := lo.FilterMap(crud.updateFields, func(field FormField, _ int) (string, bool) {
+		return field.Name, field.isComputed()
+	})
+	saved := lo.PickByKeys(values, append(lo.Keys(posts), computedNames...))
+
+	// Convert the dates from the timezone of the user to the storage format
+	if errorMessage := crud.storeDateValues(r, crud.updateFields, saved); errorMessage != "" {
+		return errorMessage
+	}
+
+	for name := range posts {
+		posts[name] = saved[name]
+	}
+
+	values = lo.Assign(lo.PickByKeys(before, crud.listUpdateNames(r)), saved)
+
+	if crud.funcUpdateChanges == nil {
+		if err := crud.funcUpdate(entityID, values); err != nil {
+			return crud.t("Save failed: {error}", "error", err.Error())
+		}
+
+		return ""
+	}
+
+	changes := lo.PickBy(values, func(name string, value string) bool {
+		previous, exists := before[name]
+		return !exists || previous != value
+	})
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	if err := crud.funcUpdateChanges(entityID, changes, before, lo.Assign(before, values)); err != nil {
+		return crud.t("Save failed: {error}", "error", err.Error())
+	}
+
+	return ""
+}
@@ -0,0 +1,133 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// readView generates the details of the entity from the ReadFields,
+// one section per field group.
+//
+// Parameters:
+// - r: the HTTP request
+// - values: the values of the entity, keyed by field name
+//
+// Returns:
+// - []hb.TagInterface - the sections
+func (crud *Crud) readView(r *http.Request, values map[string]string) []hb.TagInterface {
+	sections := []hb.TagInterface{}
+	location := crud.location(r)
+
+	visibleFields := lo.Filter(crud.readFields, func(field FormField, _ int) bool {
+		return field.isVisibleFor(r) && field.isVisible(values)
+	})
+
+	for _, group := range groupFields(visibleFields) {
+		table := hb.Table().
+			Class("table table-hover table-striped").
+			Child(hb.Tbody().Children(lo.Map(group.fields, func(field FormField, _ int) hb.TagInterface {
+				if field.Type == FORM_FIELD_TYPE_RAW {
+					return hb.TR().Child(hb.TD().Attr("colspan", "2").HTML(field.Value))
+				}
+
+				field = crud.translateField(field)
+
+				return hb.TR().Children([]hb.TagInterface{
+					hb.TH().Text(field.Label).Style("width:30%;"),
+					hb.TD().Child(crud.readValue(field, values[field.Name], location)),
+				})
+			})))
+
+		section := hb.Div().Class("mb-3")
+
+		if group.name != "" {
+			section.Child(hb.Heading5().Class("mt-2").Text(crud.fieldGroup(group.name).Label))
+		}
+
+		sections = append(sections, section.Child(table))
+	}
+
+	return sections
+}
+
+// readValue generates the display of the value of a field on the read
+// page, depending on the type and the format of the field, the dates
+// and times being in the timezone of the user
+func (crud *Crud) readValue(field FormField, value string, location *time.Location) hb.TagInterface {
+	if field.Type == FORM_FIELD_TYPE_PASSWORD {
+		return hb.Span().Text(lo.Ternary(value == "", "", "••••••••"))
+	}
+
+	if field.isBoolean() {
+		return hb.Span().
+			Class("badge").
+			ClassIf(value == "1", "bg-success").
+			ClassIf(value != "1", "bg-secondary").
+			Text(crud.t(lo.Ternary(value == "1", "Yes", "No")))
+	}
+
+	if field.isRepeater() {
+		return crud.repeaterReadValue(field, value, location)
+	}
+
+	if value == "" {
+		return hb.Span().Class("text-muted").Text("-")
+	}
+
+	if field.Format != "" && !field.isDateField() {
+		return hb.Span().Text(crud.formatValue(field.Format, field.Currency, value, location))
+	}
+
+	switch field.Type {
+	case FORM_FIELD_TYPE_IMAGE, FORM_FIELD_TYPE_IMAGE_INLINE:
+		if !isSafeURL(value, true) {
+			return hb.Span().Text(value)
+		}
+		return hb.Hyperlink().
+			Href(value).
+			Target("_blank").
+			Child(hb.Image(value).Class("img-thumbnail").Style("max-width:200px;max-height:200px;"))
+	case FORM_FIELD_TYPE_SELECT, FORM_FIELD_TYPE_RADIO:
+		return hb.Span().Text(optionLabel(field.options(), value))
+	case FORM_FIELD_TYPE_MULTISELECT, FORM_FIELD_TYPE_CHECKBOXES, FORM_FIELD_TYPE_TAGS:
+		options := field.options()
+		return hb.Div().Children(lo.Map(DecodeMultiValue(value), func(item string, _ int) hb.TagInterface {
+			return hb.Span().Class("badge bg-secondary me-1").Text(optionLabel(options, item))
+		}))
+	case FORM_FIELD_TYPE_DATETIME, FORM_FIELD_TYPE_DATE:
+		return hb.Span().Text(crud.formatDateValue(field.Format, value, location))
+	case FORM_FIELD_TYPE_DATERANGE:
+		return hb.Span().Text(strings.Join(lo.Map(DecodeMultiValue(value), func(date string, _ int) string {
+			return crud.formatDateValue(field.Format, date, location)
+		}), " – "))
+	case FORM_FIELD_TYPE_HTMLAREA, FORM_FIELD_TYPE_BLOCKAREA:
+		return hb.Div().HTML(sanitizeHTML(value))
+	case FORM_FIELD_TYPE_MARKDOWN:
+		return hb.Div().HTML(renderMarkdown(value))
+	case FORM_FIELD_TYPE_JSON:
+		return hb.NewTag("pre").Class("bg-light border rounded p-2 mb-0").Text(prettyJSON(value))
+	case FORM_FIELD_TYPE_TEXTAREA:
+		return hb.Div().Style("white-space:pre-wrap;").Text(value)
+	case FORM_FIELD_TYPE_RELATION:
+		if field.Relation == nil {
+			return hb.Span().Text(value)
+		}
+		return relationLink(field.Relation, value, field.Relation.fetchLabels([]string{value})[value])
+	}
+
+	return hb.Span().Text(value)
+}
+
+// optionLabel returns the label of the option with the specified key,
+// or the key itself if there is no such option
+func optionLabel(options []FormFieldOption, key string) string {
+	option, found := lo.Find(options, func(option FormFieldOption) bool {
+		return option.Key == key
+	})
+
+	return lo.Ternary(found, option.Value, key)
+}
@@ -0,0 +1,70 @@
+package crud
+
+import (
+	"github.com/gouniverse/hb"
+	"github.com/samber/lo"
+)
+
+// fetchLabels returns the labels of the specified entities, keyed by
+// entity ID. Entities without a label are left out, so the caller
+// can fall back to showing the ID.
+func (crud *Crud) fetchLabels(entityIDs []string) map[string]string {
+	entityIDs = lo.Uniq(lo.Compact(entityIDs))
+
+	if crud.funcFetchLabels == nil || len(entityIDs) == 0 {
+		return map[string]string{}
+	}
+
+	labels, err := crud.funcFetchLabels(entityIDs)
+
+	if err != nil || labels == nil {
+		return map[string]string{}
+	}
+
+	return labels
+}
+
+// relationLink returns the label of the related entity, linked
+// to its read page if the related Crud has one
+func relationLink(related *Crud, entityID string, label string) hb.TagInterface {
+	if label == "" {
+		label = entityID
+	}
+
+	if entityID == "" || !related.isReadEnabled() {
+		return hb.Span().Text(label)
+	}
+
+	return hb.Hyperlink().
+		Text(label).
+		Href(related.UrlEntityReadByID(entityID))
+}
+
+// relationState returns the initial state of the type-ahead selects
+// of the relation fields, keyed by "relation_" + field name, to be
+// used in the tmp object of the Vue forms
+func relationState(fields []FormField, values map[string]string) map[string]any {
+	state := map[string]any{}
+
+	for _, field := range fields {
+		if field.Type != FORM_FIELD_TYPE_RELATION || field.Relation == nil {
+			continue
+		}
+
+		label := ""
+		if entityID := values[field.Name]; entityID != "" {
+			label = lo.ValueOr(field.Relation.fetchLabels([]string{entityID}), entityID, entityID)
+		}
+
+		state["relation_"+field.Name] = map[string]any{
+			"query":   "",
+			"label":   label,
+			"options": []FormFieldOption{},
+			"page":    1,
+			"hasMore": false,
+			"open":    false,
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,207 @@
+package crud
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// repeaterRequestValue normalizes the posted items of a repeater field,
+// keeping only the values of the nested fields
+func repeaterRequestValue(field FormField, value string) string {
+	items := lo.Map(DecodeRepeaterValue(value), func(posted map[string]string, _ int) map[string]string {
+		item := map[string]string{}
+
+		for _, nested := range field.Fields {
+			if nested.Name == "" {
+				continue
+			}
+
+			nestedValue := posted[nested.Name]
+
+			if nested.isBoolean() {
+				nestedValue = lo.Ternary(isTruthy(nestedValue), "1", "0")
+			}
+
+			item[nested.Name] = nestedValue
+		}
+
+		return item
+	})
+
+	return EncodeRepeaterValue(items)
+}
+
+// repeaterItemsError validates each item of a repeater field against
+// the nested fields, skipping the fields hidden by their conditions
+//
+// Parameters:
+// - field: the repeater field
+// - value: the encoded items
+//
+// Returns:
+// - string - the error message, or an empty string if all is filled in
+func (crud *Crud) repeaterItemsError(field FormField, value string) string {
+	label := lo.Ternary(field.Label == "", field.Name, field.Label)
+
+	for index, item := range DecodeRepeaterValue(value) {
+		if errorMessage := crud.validateFields(field.Fields, item); errorMessage != "" {
+			return crud.t(label) + " #" + strconv.Itoa(index+1) + ": " + errorMessage
+		}
+	}
+
+	return ""
+}
+
+// repeaterDefaults returns the values of a new item of a repeater field
+func repeaterDefaults(field FormField) map[string]string {
+	item := map[string]string{}
+
+	for _, nested := range field.Fields {
+		if nested.Name == "" {
+			continue
+		}
+		item[nested.Name] = nested.Value
+	}
+
+	return item
+}
+
+// repeaterInput generates the list of the items of a repeater field,
+// each with the nested fields and the controls for removing and
+// reordering it, followed by a button for adding a new item
+func (crud *Crud) repeaterInput(field FormField) hb.TagInterface {
+	defaults, _ := utils.ToJSON(repeaterDefaults(field))
+	itemsExpression := "entityModel." + field.Name
+
+	controls := hb.Div().
+		Class("btn-group btn-group-sm").
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)).
+			Attr("title", crud.t("Move up")).
+			Attr("v-bind:disabled", "index === 0").
+			Attr("v-on:click", "repeaterMove('"+field.Name+"', index, -1)").
+			Text("↑")).
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)).
+			Attr("title", crud.t("Move down")).
+			Attr("v-bind:disabled", "index === "+itemsExpression+".length - 1").
+			Attr("v-on:click", "repeaterMove('"+field.Name+"', index, 1)").
+			Text("↓")).
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_DANGER, false)).
+			Attr("title", crud.t("Remove")).
+			Attr("v-on:click", "repeaterRemove('"+field.Name+"', index)").
+			Text("×"))
+
+	item := hb.Div().
+		Class("card mb-2").
+		Attr("v-for", "(item, index) in "+itemsExpression).
+		Attr("v-bind:key", "index").
+		Child(hb.Div().
+			Class("card-header d-flex justify-content-between align-items-center py-1").
+			Child(hb.Span().Class("text-muted").Text("#{{ index + 1 }}")).
+			Child(controls)).
+		Child(hb.Div().
+			Class("card-body pt-0").
+			Child(hb.Div().Class("row").Children(lo.Map(field.Fields, func(nested FormField, _ int) hb.TagInterface {
+				return crud.repeaterItemField(crud.translateField(nested))
+			}))))
+
+	buttonAdd := hb.Button().
+		Type(hb.TYPE_BUTTON).
+		Class(crud.theme.ButtonClass(BUTTON_OUTLINE_PRIMARY, true)).
+		Attr("v-on:click", "repeaterAdd('"+field.Name+"', "+defaults+")").
+		Text("+ " + crud.t("Add"))
+
+	return hb.Div().
+		Child(item).
+		Child(buttonAdd)
+}
+
+// repeaterItemField generates the form group of a translated nested
+// field of a repeater item, bound to the item of the v-for loop
+func (crud *Crud) repeaterItemField(field FormField) hb.TagInterface {
+	model := "item." + field.Name
+	fieldLabel := field.Label
+
+	label := hb.Label().
+		Class("form-label").
+		Text(fieldLabel).
+		Child(requiredMarker(field))
+
+	input := hb.Input().
+		Type(hb.TYPE_TEXT).
+		Class(crud.theme.InputClass(field.Type)).
+		Attr("v-model", model)
+
+	switch field.Type {
+	case FORM_FIELD_TYPE_NUMBER:
+		input.Type(hb.TYPE_NUMBER)
+	case FORM_FIELD_TYPE_PASSWORD:
+		input.Type(hb.TYPE_PASSWORD)
+	case FORM_FIELD_TYPE_TEXTAREA:
+		input = hb.TextArea().Class(crud.theme.InputClass(field.Type)).Attr("v-model", model)
+	case FORM_FIELD_TYPE_SELECT:
+		input = hb.Select().Class(crud.theme.InputClass(field.Type)).Attr("v-model", model).
+			Children(lo.Map(field.options(), func(option FormFieldOption, _ int) hb.TagInterface {
+				return hb.Option().Value(option.Key).Text(option.Value)
+			}))
+	case FORM_FIELD_TYPE_CHECKBOX, FORM_FIELD_TYPE_SWITCH:
+		input = hb.Div().
+			Class("form-check").
+			ClassIf(field.Type == FORM_FIELD_TYPE_SWITCH, "form-switch").
+			Child(hb.Label().
+				Class("form-check-label").
+				Child(hb.Input().
+					Type(hb.TYPE_CHECKBOX).
+					Class("form-check-input").
+					Attr("true-value", "1").
+					Attr("false-value", "0").
+					Attr("v-model", model)).
+				Text(" " + fieldLabel).
+				Child(requiredMarker(field)))
+	}
+
+	column := hb.Div().
+		Class(fieldColumnClass(field)).
+		Child(crud.theme.FormGroup(lo.Ternary[hb.TagInterface](field.isBoolean(), nil, label), input, field.Help))
+
+	if len(field.ShowIf) > 0 {
+		showIf, _ := utils.ToJSON(field.ShowIf)
+		column.Attr("v-show", "itemConditionsMatch(item, "+showIf+")")
+	}
+
+	return column
+}
+
+// repeaterReadValue generates a table with the items of a repeater
+// field for the read page, one column per nested field
+func (crud *Crud) repeaterReadValue(field FormField, value string, location *time.Location) hb.TagInterface {
+	items := DecodeRepeaterValue(value)
+
+	if len(items) == 0 {
+		return hb.Span().Class("text-muted").Text("-")
+	}
+
+	nestedFields := lo.FilterMap(field.Fields, func(nested FormField, _ int) (FormField, bool) {
+		return crud.translateField(nested), nested.Name != "" && nested.Type != FORM_FIELD_TYPE_RAW
+	})
+
+	return hb.Table().
+		Class("table table-sm table-bordered mb-0").
+		Child(hb.Thead().Child(hb.TR().Children(lo.Map(nestedFields, func(nested FormField, _ int) hb.TagInterface {
+			return hb.TH().Text(nested.Label)
+		})))).
+		Child(hb.Tbody().Children(lo.Map(items, func(item map[string]string, _ int) hb.TagInterface {
+			return hb.TR().Children(lo.Map(nestedFields, func(nested FormField, _ int) hb.TagInterface {
+				return hb.TD().Child(crud.readValue(nested, item[nested.Name], location))
+			}))
+		})))
+}
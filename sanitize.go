@@ -0,0 +1,150 @@
+package crud
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/net/html"
+)
+
+// sanitizeAllowedTags are the tags kept by sanitizeHTML, with the
+// attributes allowed for each of them
+var sanitizeAllowedTags = map[string][]string{
+	"a":          {"href", "title", "target"},
+	"abbr":       {"title"},
+	"b":          {},
+	"blockquote": {},
+	"br":         {},
+	"code":       {},
+	"dd":         {},
+	"del":        {},
+	"div":        {},
+	"dl":         {},
+	"dt":         {},
+	"em":         {},
+	"h1":         {},
+	"h2":         {},
+	"h3":         {},
+	"h4":         {},
+	"h5":         {},
+	"h6":         {},
+	"hr":         {},
+	"i":          {},
+	"img":        {"src", "alt", "title", "width", "height"},
+	"li":         {},
+	"ol":         {},
+	"p":          {},
+	"pre":        {},
+	"s":          {},
+	"span":       {},
+	"strong":     {},
+	"sub":        {},
+	"sup":        {},
+	"table":      {},
+	"tbody":      {},
+	"td":         {"colspan", "rowspan"},
+	"th":         {"colspan", "rowspan"},
+	"thead":      {},
+	"tr":         {},
+	"u":          {},
+	"ul":         {},
+}
+
+// sanitizeDroppedTags are the tags removed together with their content
+var sanitizeDroppedTags = []string{"script", "style", "iframe", "object", "embed", "noscript", "template", "textarea", "select"}
+
+// sanitizeHTML removes the tags and attributes not in the allow list
+// from the HTML, keeping the text. Links and images are only allowed
+// to point to http(s), mailto and relative URLs, and images to inline
+// data as well.
+//
+// Parameters:
+// - input: the HTML to sanitize
+//
+// Returns:
+// - string - the sanitized HTML
+func sanitizeHTML(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	output := bytes.Buffer{}
+	dropDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+
+		// io.EOF at the end of the input, or a malformed input
+		if tokenType == html.ErrorToken {
+			return output.String()
+		}
+
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if lo.Contains(sanitizeDroppedTags, token.Data) {
+				if tokenType == html.StartTagToken {
+					dropDepth++
+				}
+				continue
+			}
+
+			allowedAttributes, allowed := sanitizeAllowedTags[token.Data]
+			if dropDepth > 0 || !allowed {
+				continue
+			}
+
+			token.Attr = lo.Filter(token.Attr, func(attribute html.Attribute, _ int) bool {
+				if !lo.Contains(allowedAttributes, attribute.Key) {
+					return false
+				}
+
+				if attribute.Key == "href" || attribute.Key == "src" {
+					return isSafeURL(attribute.Val, token.Data == "img")
+				}
+
+				return true
+			})
+
+			if token.Data == "a" {
+				token.Attr = append(token.Attr, html.Attribute{Key: "rel", Val: "noopener noreferrer"})
+			}
+
+			output.WriteString(token.String())
+		case html.EndTagToken:
+			if lo.Contains(sanitizeDroppedTags, token.Data) {
+				dropDepth = max(dropDepth-1, 0)
+				continue
+			}
+
+			if _, allowed := sanitizeAllowedTags[token.Data]; dropDepth > 0 || !allowed {
+				continue
+			}
+
+			output.WriteString(token.String())
+		case html.TextToken:
+			if dropDepth > 0 {
+				continue
+			}
+
+			output.WriteString(token.String())
+		}
+	}
+}
+
+// isSafeURL returns true if the URL is safe to use in a link or an image
+func isSafeURL(url string, isImage bool) bool {
+	url = strings.ToLower(strings.TrimSpace(url))
+
+	if isImage && strings.HasPrefix(url, "data:image/") {
+		return true
+	}
+
+	if strings.HasPrefix(url, "http://") ||
+		strings.HasPrefix(url, "https://") ||
+		strings.HasPrefix(url, "mailto:") {
+		return true
+	}
+
+	// relative URLs, without a scheme
+	return !strings.Contains(strings.SplitN(url, "/", 2)[0], ":")
+}
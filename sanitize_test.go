@@ -0,0 +1,24 @@
+package crud
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := map[string]string{
+		`<p>Hello <b>World</b></p>`:                       `<p>Hello <b>World</b></p>`,
+		`<p onclick="alert(1)">Hi</p>`:                    `<p>Hi</p>`,
+		`<script>alert(1)</script><p>Hi</p>`:              `<p>Hi</p>`,
+		`<a href="javascript:alert(1)">Link</a>`:          `<a rel="noopener noreferrer">Link</a>`,
+		`<a href="https://example.com">Link</a>`:          `<a href="https://example.com" rel="noopener noreferrer">Link</a>`,
+		`<img src="/image.png" onerror="alert(1)">`:       `<img src="/image.png">`,
+		`<iframe src="https://example.com"></iframe>Text`: `Text`,
+		`<form><input name="x"><span>Kept</span></form>`:  `<span>Kept</span>`,
+		`<div style="position:fixed">Styled</div>`:        `<div>Styled</div>`,
+		`1 &lt; 2`: `1 &lt; 2`,
+	}
+
+	for input, expected := range tests {
+		if sanitized := sanitizeHTML(input); sanitized != expected {
+			t.Error("Sanitized "+input+" MUST be "+expected+", but found: ", sanitized)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package crud
+
+// scriptFormHelpers contains the global JavaScript helpers
+// used by the Vue forms
+const scriptFormHelpers = `
+function crudSerializeModel(model) {
+	const data = {};
+	Object.keys(model).forEach((key) => {
+		const value = model[key];
+		const isObject = value !== null && typeof value === "object";
+		data[key] = isObject ? JSON.stringify(value) : value;
+	});
+	return data;
+}
+function crudConditionsMatch(model, conditions) {
+	return (conditions || []).every((condition) => {
+		const value = model[condition.Field];
+		const selected = Array.isArray(value) ? value.map(String) : [value === null || value === undefined ? "" : String(value)];
+		const isEmpty = selected.filter((item) => item !== "").length === 0;
+		const values = condition.Values || [];
+		switch (condition.Operator) {
+			case "neq": return !selected.includes(condition.Value);
+			case "in": return selected.some((item) => values.includes(item));
+			case "not_in": return !selected.some((item) => values.includes(item));
+			case "empty": return isEmpty;
+			case "not_empty": return !isEmpty;
+		}
+		return selected.includes(condition.Value);
+	});
+}
+function crudAppendQuery(url, query) {
+	return url + (url.includes("?") ? "&" : "?") + query;
+}
+function crudSlug(text) {
+	return String(text === null || text === undefined ? "" : text).toLowerCase().split(/[^\p{L}\p{N}]+/u).filter(Boolean).join("-");
+}
+`
+
+// scriptFormMethods contains the Vue methods used by the form fields,
+// shared by the create and the update forms
+const scriptFormMethods = `
+		tagAdd(fieldName, event){
+			const tag = event.target.value.trim();
+			event.target.value = "";
+			if (tag === "") return;
+			if (!Array.isArray(this.entityModel[fieldName])) this.entityModel[fieldName] = [];
+			if (this.entityModel[fieldName].includes(tag)) return;
+			this.entityModel[fieldName].push(tag);
+		},
+		tagRemove(fieldName, index){
+			this.entityModel[fieldName].splice(index, 1);
+		},
+		relationSearch(fieldName, url, page){
+			const state = this.tmp["relation_" + fieldName];
+			clearTimeout(state.timer);
+			state.timer = setTimeout(() => {
+				$.get(url, {q: state.query, page: page}).done((response)=>{
+					if (response.status !== "success") {
+						return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+					}
+					const options = response.data.options || [];
+					state.options = page > 1 ? state.options.concat(options) : options;
+					state.page = page;
+					state.hasMore = response.data.has_more;
+					state.open = true;
+				});
+			}, page > 1 ? 0 : 300);
+		},
+		relationSelect(fieldName, option){
+			const state = this.tmp["relation_" + fieldName];
+			this.entityModel[fieldName] = option.key;
+			state.label = option.value;
+			state.query = "";
+			state.open = false;
+		},
+		repeaterAdd(fieldName, defaults){
+			if (!Array.isArray(this.entityModel[fieldName])) this.entityModel[fieldName] = [];
+			this.entityModel[fieldName].push(JSON.parse(JSON.stringify(defaults)));
+		},
+		repeaterRemove(fieldName, index){
+			this.entityModel[fieldName].splice(index, 1);
+		},
+		repeaterMove(fieldName, index, offset){
+			const items = this.entityModel[fieldName];
+			const target = index + offset;
+			if (target < 0 || target >= items.length) return;
+			items.splice(target, 0, items.splice(index, 1)[0]);
+		},
+		itemConditionsMatch(item, conditions){
+			return crudConditionsMatch(item, conditions);
+		},
+		markdownWrap(fieldName, event, before, after){
+			const textarea = event.target.closest(".nav-tabs + div").querySelector("textarea");
+			const value = this.entityModel[fieldName] || "";
+			const start = textarea.selectionStart;
+			const end = textarea.selectionEnd;
+			this.entityModel[fieldName] = value.slice(0, start) + before + value.slice(start, end) + after + value.slice(end);
+			this.$nextTick(() => {
+				textarea.focus();
+				textarea.setSelectionRange(start + before.length, end + before.length);
+			});
+		},
+		markdownPreview(fieldName, url){
+			const state = this.tmp["markdown_" + fieldName];
+			state.tab = "preview";
+			$.post(url, {markdown: this.entityModel[fieldName] || ""}).done((response)=>{
+				if (response.status !== "success") {
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
+				}
+				state.html = response.data.html;
+			});
+		},
+		jsonHighlight(text){
+			const escaped = String(text || "").replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;");
+			const pattern = /("(\\u[a-fA-F0-9]{4}|\\[^u]|[^\\"])*"(\s*:)?|\b(true|false|null)\b|-?\d+(\.\d*)?([eE][+\-]?\d+)?)/g;
+			return escaped.replace(pattern, (match) => {
+				let color = "#b45309";
+				if (/^"/.test(match)) color = /:$/.test(match) ? "#1d4ed8" : "#15803d";
+				else if (/true|false/.test(match)) color = "#7c3aed";
+				else if (/null/.test(match)) color = "#6b7280";
+				return '<span style="color:' + color + '">' + match + '</span>';
+			}) + "\n";
+		},
+		jsonError(text){
+			if (String(text || "").trim() === "") return "";
+			try {
+				JSON.parse(text);
+				return "";
+			} catch (error) {
+				return error.message;
+			}
+		},
+		jsonFormat(fieldName){
+			const text = this.entityModel[fieldName];
+			if (this.jsonError(text) !== "" || String(text || "").trim() === "") return;
+			this.entityModel[fieldName] = JSON.stringify(JSON.parse(text), null, 2);
+		},
+		conditionsMatch(conditions){
+			return crudConditionsMatch(this.entityModel, conditions);
+		},
+		optionsWatch(configs){
+			(configs || []).forEach((config) => {
+				this.$watch(() => JSON.stringify(config.dependsOn.map((name) => this.entityModel[name])), () => this.optionsLoad(config));
+			});
+		},
+		optionsLoad(config){
+			$.post(config.url, crudSerializeModel(this.entityModel)).done((response)=>{
+				if (response.status !== "success") return;
+				const options = response.data.options || [];
+				this.tmp["options_" + config.field] = options;
+				const value = this.entityModel[config.field];
+				const keys = options.map((option) => option.key);
+				if (Array.isArray(value)) {
+					this.entityModel[config.field] = value.filter((item) => keys.includes(item));
+				} else if (value !== "" && value !== null && value !== undefined && !keys.includes(value)) {
+					this.entityModel[config.field] = "";
+				}
+			});
+		},
+		computedWatch(configs){
+			(configs || []).forEach((config) => {
+				const compute = new Function("values", "return (" + config.expression + ");");
+				const preview = () => {
+					const value = compute(this.entityModel);
+					return value === null || value === undefined ? "" : String(value);
+				};
+				this.$watch(preview, (value, previous) => {
+					const current = this.entityModel[config.field];
+					if (current !== undefined && current !== null && current !== "" && current !== previous) return;
+					this.entityModel[config.field] = value;
+				});
+			});
+		},
+		relationClear(fieldName){
+			const state = this.tmp["relation_" + fieldName];
+			this.entityModel[fieldName] = "";
+			state.label = "";
+			state.query = "";
+			state.open = false;
+		},
+`
@@ -0,0 +1,122 @@
+package crud
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogTranslator translates with message catalogs, one per locale,
+// falling back to the language of the locale, e.g. "de" for "de-AT",
+// then to the fallback locale, then to the key
+type CatalogTranslator struct {
+	fallbackLocale string
+	catalogs       map[string]map[string]string
+	mutex          sync.RWMutex
+}
+
+var _ Translator = (*CatalogTranslator)(nil)
+
+// NewCatalogTranslator creates a translator without messages, the keys
+// being shown until the catalogs are added
+//
+// Parameters:
+// - fallbackLocale: the locale used for the keys missing in the locale of the request
+//
+// Returns:
+// - *CatalogTranslator - the translator
+func NewCatalogTranslator(fallbackLocale string) *CatalogTranslator {
+	return &CatalogTranslator{
+		fallbackLocale: normalizeLocale(fallbackLocale),
+		catalogs:       map[string]map[string]string{},
+	}
+}
+
+// AddMessages adds the messages, by key, to the catalog of the locale
+func (translator *CatalogTranslator) AddMessages(locale string, messages map[string]string) {
+	translator.mutex.Lock()
+	defer translator.mutex.Unlock()
+
+	locale = normalizeLocale(locale)
+
+	if translator.catalogs[locale] == nil {
+		translator.catalogs[locale] = map[string]string{}
+	}
+
+	for key, message := range messages {
+		translator.catalogs[locale][key] = message
+	}
+}
+
+// LoadFS adds the catalogs in the directory, e.g. of an embed.FS, one
+// file per locale named after it, e.g. "de.json" or "bg.yaml", with the
+// messages by key
+//
+// Parameters:
+// - fsys: the file system
+// - dir: the directory of the catalogs
+//
+// Returns:
+// - error - if a catalog cannot be read or parsed
+func (translator *CatalogTranslator) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name())
+
+		if entry.IsDir() || !lo.Contains([]string{".json", ".yaml", ".yml"}, extension) {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+
+		if err != nil {
+			return err
+		}
+
+		messages := map[string]string{}
+
+		if extension == ".json" {
+			err = json.Unmarshal(data, &messages)
+		} else {
+			err = yaml.Unmarshal(data, &messages)
+		}
+
+		if err != nil {
+			return errors.New("Catalog " + entry.Name() + ": " + err.Error())
+		}
+
+		translator.AddMessages(strings.TrimSuffix(entry.Name(), extension), messages)
+	}
+
+	return nil
+}
+
+func (translator *CatalogTranslator) Translate(locale string, key string, params map[string]string) string {
+	translator.mutex.RLock()
+	defer translator.mutex.RUnlock()
+
+	locale = normalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	message := key
+
+	for _, candidate := range []string{locale, language, translator.fallbackLocale} {
+		if translated, found := translator.catalogs[candidate][key]; found {
+			message = translated
+			break
+		}
+	}
+
+	return replacePlaceholders(message, params)
+}
@@ -0,0 +1,149 @@
+package crud
+
+import (
+	"strings"
+
+	"github.com/gouniverse/form"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// ChoiceField is a form field for the choice types not provided
+// by the form package (multiselect, checkboxes, radio, checkbox,
+// switch and tags). Any other type is built by the embedded form.Field.
+//
+// Multi-valued fields post repeated keys and the value of the field
+// is a JSON array (see EncodeMultiValue).
+type ChoiceField struct {
+	*form.Field
+}
+
+var _ form.FieldInterface = (*ChoiceField)(nil)
+
+// tagsPlaceholder is the placeholder of the tags fields without one
+const tagsPlaceholder = "Comma separated, e.g. red, green"
+
+// NewChoiceField creates a new choice field
+func NewChoiceField(opts form.FieldOptions) *ChoiceField {
+	return &ChoiceField{
+		Field: form.NewField(opts),
+	}
+}
+
+func (field *ChoiceField) BuildFormGroup(fileManagerURL string) *hb.Tag {
+	if !isMultiValued(field) && !isBoolean(field) && field.Type != FORM_FIELD_TYPE_RADIO {
+		return field.Field.BuildFormGroup(fileManagerURL)
+	}
+
+	if field.ID == "" {
+		field.ID = "id_" + utils.StrRandomFromGamma(32, "abcdefghijklmnopqrstuvwxyz1234567890")
+	}
+
+	fieldLabel := lo.Ternary(field.Label == "", field.Name, field.Label)
+
+	formGroupLabel := hb.Label().
+		HTML(fieldLabel).
+		Class("form-label").
+		ChildIf(
+			field.Required,
+			hb.Sup().HTML("*").Class("text-danger ml-1"),
+		)
+
+	formGroup := hb.Div().
+		Class("form-group mb-3")
+
+	if !isBoolean(field) {
+		formGroup.Child(formGroupLabel)
+	}
+
+	formGroup.Child(field.input(fieldLabel))
+
+	if field.Invisible {
+		formGroup.Attr("style", "display:none;")
+	}
+
+	if field.Help != "" {
+		formGroup.Child(hb.Paragraph().Class("text-info").HTML(field.Help))
+	}
+
+	return formGroup
+}
+
+func (field *ChoiceField) input(fieldLabel string) *hb.Tag {
+	values := lo.Ternary(isMultiValued(field), DecodeMultiValue(field.Value), []string{field.Value})
+
+	if field.Type == FORM_FIELD_TYPE_MULTISELECT {
+		input := hb.Select().
+			ID(field.ID).
+			Name(field.Name).
+			Class("form-select").
+			Attr("multiple", "multiple")
+
+		for _, opt := range fieldOptions(field) {
+			input.Child(hb.Option().
+				Value(opt.Key).
+				HTML(opt.Value).
+				AttrIf(lo.Contains(values, opt.Key), "selected", "selected"))
+		}
+
+		return input
+	}
+
+	if field.Type == FORM_FIELD_TYPE_CHECKBOXES || field.Type == FORM_FIELD_TYPE_RADIO {
+		inputType := lo.Ternary(field.Type == FORM_FIELD_TYPE_RADIO, hb.TYPE_RADIO, hb.TYPE_CHECKBOX)
+		input := hb.Div().ID(field.ID)
+
+		for index, opt := range fieldOptions(field) {
+			optionID := field.ID + "_" + utils.ToString(index)
+			input.Child(hb.Div().Class("form-check").
+				Child(hb.Input().
+					Type(inputType).
+					Class("form-check-input").
+					ID(optionID).
+					Name(field.Name).
+					Value(opt.Key).
+					AttrIf(lo.Contains(values, opt.Key), "checked", "checked")).
+				Child(hb.Label().
+					Class("form-check-label").
+					Attr("for", optionID).
+					HTML(opt.Value)))
+		}
+
+		return input
+	}
+
+	if field.Type == FORM_FIELD_TYPE_TAGS {
+		return hb.Input().
+			ID(field.ID).
+			Type(hb.TYPE_TEXT).
+			Class("form-control").
+			Name(field.Name).
+			Value(strings.Join(values, ", ")).
+			Placeholder(lo.CoalesceOrEmpty(field.Placeholder, tagsPlaceholder))
+	}
+
+	// checkbox and switch, the hidden input posts "0" when unchecked
+	return hb.Div().
+		Class("form-check").
+		ClassIf(field.Type == FORM_FIELD_TYPE_SWITCH, "form-switch").
+		Child(hb.Input().
+			Type(hb.TYPE_HIDDEN).
+			Name(field.Name).
+			Value("0")).
+		Child(hb.Input().
+			Type(hb.TYPE_CHECKBOX).
+			Class("form-check-input").
+			ID(field.ID).
+			Name(field.Name).
+			Value("1").
+			AttrIf(isTruthy(field.Value), "checked", "checked")).
+		Child(hb.Label().
+			Class("form-check-label").
+			Attr("for", field.ID).
+			HTML(fieldLabel).
+			ChildIf(
+				field.Required,
+				hb.Sup().HTML("*").Class("text-danger ml-1"),
+			))
+}
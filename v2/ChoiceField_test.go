@@ -0,0 +1,42 @@
+package crud
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gouniverse/form"
+)
+
+func TestChoiceFieldCheckboxGroupWithOneValue(t *testing.T) {
+	group := NewChoiceField(form.FieldOptions{
+		ID:      "colors",
+		Type:    FORM_FIELD_TYPE_CHECKBOXES,
+		Name:    "colors",
+		Options: []form.FieldOption{{Key: "red", Value: "Red"}, {Key: "green", Value: "Green"}},
+		Value:   `["red"]`,
+	}).BuildFormGroup("").ToHTML()
+
+	if !strings.Contains(group, `checked="checked" class="form-check-input" id="colors_0" name="colors" type="checkbox" value="red"`) {
+		t.Error("Checkbox group MUST check the only value, but found: ", group)
+	}
+
+	// the fill script tells the boolean checkboxes by their hidden "0" input
+	if strings.Contains(group, `type="hidden"`) {
+		t.Error("Checkbox group MUST NOT have a hidden input, but found: ", group)
+	}
+
+	checkbox := NewChoiceField(form.FieldOptions{
+		ID:    "active",
+		Type:  FORM_FIELD_TYPE_CHECKBOX,
+		Name:  "active",
+		Value: "1",
+	}).BuildFormGroup("").ToHTML()
+
+	if !strings.Contains(checkbox, `name="active" type="hidden" value="0"`) {
+		t.Error("Checkbox MUST have a hidden input, but found: ", checkbox)
+	}
+
+	if strings.Contains(scriptConditions, "selected.length === 1") || !strings.Contains(scriptConditions, `const isBoolean = element.type === "checkbox" && container.querySelector('input[type=hidden]`) {
+		t.Error("Fill script MUST tell the boolean checkboxes by their hidden input")
+	}
+}
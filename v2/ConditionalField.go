@@ -0,0 +1,69 @@
+package crud
+
+import (
+	"github.com/gouniverse/form"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+)
+
+// ConditionalField wraps a form field, adding the conditions for
+// showing it and making it required, and the options depending on
+// the values of other fields.
+//
+// Example:
+//
+//	&crud.ConditionalField{
+//		FieldInterface: form.NewField(form.FieldOptions{Type: form.FORM_FIELD_TYPE_STRING, Name: "company_name", Label: "Company Name", Required: true}),
+//		ShowIf:         []crud.FieldCondition{{Field: "type", Value: "business"}},
+//	}
+type ConditionalField struct {
+	form.FieldInterface
+
+	// ShowIf are the conditions, all of which must match, for the field
+	// to be shown. Hidden fields are not required
+	ShowIf []FieldCondition
+
+	// RequiredIf are the conditions, all of which must match, for the
+	// field to be required, in addition to the Required flag
+	RequiredIf []FieldCondition
+
+	// DependsOn are the names of the fields the options of the field
+	// depend on. The options are reloaded when any of them changes
+	DependsOn []string
+
+	// DependentOptionsF returns the options of the field for the current
+	// values of the form, added after Options and OptionsF
+	DependentOptionsF func(values map[string]string) []form.FieldOption
+
+	// optionsURL is the URL for reloading the dependent options,
+	// set by the Crud the field belongs to
+	optionsURL string
+}
+
+var _ form.FieldInterface = (*ConditionalField)(nil)
+
+// BuildFormGroup builds the form group of the wrapped field, with the
+// conditions as data attributes evaluated by scriptConditions
+func (field *ConditionalField) BuildFormGroup(fileManagerURL string) *hb.Tag {
+	formGroup := field.FieldInterface.BuildFormGroup(fileManagerURL)
+
+	if len(field.ShowIf) > 0 {
+		showIf, _ := utils.ToJSON(field.ShowIf)
+		formGroup.Attr("data-crud-show-if", showIf)
+	}
+
+	if len(field.RequiredIf) > 0 {
+		requiredIf, _ := utils.ToJSON(field.RequiredIf)
+		formGroup.Attr("data-crud-required-if", requiredIf)
+	}
+
+	if field.DependentOptionsF != nil && len(field.DependsOn) > 0 && field.optionsURL != "" {
+		dependsOn, _ := utils.ToJSON(field.DependsOn)
+		formGroup.
+			Attr("data-crud-field", field.GetName()).
+			Attr("data-crud-depends-on", dependsOn).
+			Attr("data-crud-options-url", field.optionsURL)
+	}
+
+	return formGroup
+}
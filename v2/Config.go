@@ -15,14 +15,18 @@ type Config struct {
 	EntityNameSingular  string
 	FileManagerURL      string
 	FuncCreate          func(data map[string]string) (userID string, err error)
+	FuncCreateDefaults  func(r *http.Request) map[string]string
 	FuncFetchReadData   func(entityID string) ([][2]string, error)
 	FuncFetchUpdateData func(entityID string) (map[string]string, error)
+	FuncLocale          func(r *http.Request) string
 	FuncLayout          func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
 	FuncRows            func() (rows []Row, err error)
 	FuncTrash           func(entityID string) error
 	FuncUpdate          func(entityID string, data map[string]string) error
 	HomeURL             string
+	PathRouting         bool
 	ReadFields          []form.FieldInterface
+	Translator          Translator
 	UpdateFields        []form.FieldInterface
 	FuncReadExtras      func(entityID string) []hb.TagInterface
 }
@@ -21,25 +21,46 @@ type Crud struct {
 	entityNameSingular  string
 	fileManagerURL      string
 	funcCreate          func(data map[string]string) (userID string, err error)
+	funcCreateDefaults  func(r *http.Request) map[string]string
 	funcReadExtras      func(entityID string) []hb.TagInterface
 	funcFetchReadData   func(entityID string) ([][2]string, error)
 	funcFetchUpdateData func(entityID string) (map[string]string, error)
+	funcLocale          func(r *http.Request) string
 	funcLayout          func(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string
 	funcRows            func() (rows []Row, err error)
 	funcTrash           func(entityID string) error
 	funcUpdate          func(entityID string, data map[string]string) error
 	homeURL             string
+	locale              string
+	pathRouting         bool
 	readFields          []form.FieldInterface
+	translator          Translator
 	updateFields        []form.FieldInterface
 }
 
 func (crud Crud) Handler(w http.ResponseWriter, r *http.Request) {
-	path := utils.Req(r, "path", pathHome)
+	path := utils.Req(r, "path", "")
+
+	// the path query parameter takes precedence, so that the URLs
+	// of the query routing keep working with the path routing
+	if path == "" && crud.pathRouting {
+		route, routed, found := pathRoute(r)
+
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		path, r = route, routed
+	}
 
 	if path == "" {
 		path = pathHome
 	}
 
+	// the Crud is a copy for the request, shown in its locale
+	crud.locale = requestLocale(crud.funcLocale, r)
+
 	ctx := context.WithValue(r.Context(), "", r.URL.Path)
 
 	routeFunc := crud.getRoute(path)
@@ -52,6 +73,7 @@ func (crud *Crud) getRoute(route string) func(w http.ResponseWriter, r *http.Req
 		pathEntityCreateAjax:  crud.newEntityCreateController().modalSave,
 		pathEntityCreateModal: crud.newEntityCreateController().modalShow,
 		pathEntityManager:     crud.newEntityManagerController().page,
+		pathEntityOptionsAjax: crud.newEntityOptionsController().pageOptionsAjax,
 		pathEntityRead:        crud.newEntityReadController().page,
 		pathEntityUpdate:      crud.newEntityUpdateController().page,
 		pathEntityUpdateAjax:  crud.newEntityUpdateController().pageSave,
@@ -96,6 +118,10 @@ func (crud *Crud) urlHome() string {
 }
 
 func (crud *Crud) UrlEntityManager() string {
+	if crud.pathRouting {
+		return crud.endpoint
+	}
+
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityManager
 	return url
@@ -113,6 +139,19 @@ func (crud *Crud) UrlEntityCreateAjax() string {
 	return url
 }
 
+// UrlEntityCreate returns the URL of the entity manager opening the
+// create modal, prefilled with the values, e.g. to create a child
+// entity from the page of the parent
+func (crud *Crud) UrlEntityCreate(values map[string]string) string {
+	return appendQuery(crud.UrlEntityManager(), "create=1"+lo.Ternary(len(values) == 0, "", "&"+encodeQuery(values)))
+}
+
+func (crud *Crud) UrlEntityOptionsAjax() string {
+	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
+	url := crud.endpoint + q + "path=" + pathEntityOptionsAjax
+	return url
+}
+
 func (crud *Crud) UrlEntityTrashAjax() string {
 	q := lo.Ternary(strings.Contains(crud.endpoint, "?"), "&", "?")
 	url := crud.endpoint + q + "path=" + pathEntityTrashAjax
@@ -225,6 +264,8 @@ func (crud *Crud) _breadcrumbs(breadcrumbs []Breadcrumb) string {
 func (crud *Crud) layout(w http.ResponseWriter, r *http.Request, title string, content string, styleFiles []string, style string, jsFiles []string, js string) string {
 	html := ""
 
+	js = crud.scriptTranslations() + js
+
 	if crud.funcLayout != nil {
 		// jsFiles = append([]string{"//unpkg.com/naive-ui"}, jsFiles...)
 		jsFiles = append([]string{cdn.VueElementPlusJs_2_3_8()}, jsFiles...)
@@ -254,7 +295,7 @@ func (crud *Crud) layout(w http.ResponseWriter, r *http.Request, title string, c
 // - a slice of hb.Tags representing the form.
 func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 	tags := []hb.TagInterface{}
-	for _, field := range fields {
+	for _, field := range crud.translateFields(fields) {
 		fieldID := field.GetID()
 		if fieldID == "" {
 			fieldID = "id_" + utils.StrRandomFromGamma(32, "abcdefghijklmnopqrstuvwxyz1234567890")
@@ -271,10 +312,7 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 		formGroupLabel := hb.Label().
 			Text(fieldLabel).
 			Class("form-label").
-			ChildIf(
-				field.GetRequired(),
-				hb.Sup().Text("*").Class("text-danger ml-1"),
-			)
+			Child(requiredMarker(field))
 
 		formGroupInput := hb.Input().
 			Class("form-control").
@@ -288,7 +326,7 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 				bs.InputGroup().Children([]hb.TagInterface{
 					hb.Input().Type(hb.TYPE_URL).Class("form-control").Attr("v-model", "entityModel."+fieldName),
 					hb.If(crud.fileManagerURL != "", bs.InputGroupText().Children([]hb.TagInterface{
-						hb.Hyperlink().Text("Browse").Href(crud.fileManagerURL).Target("_blank"),
+						hb.Hyperlink().Text(crud.t("Browse")).Href(crud.fileManagerURL).Target("_blank"),
 					})),
 				}),
 			})
@@ -305,7 +343,7 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 						Attr("v-on:change", "uploadImage($event, '"+fieldName+"')").
 						Attr("accept", "image/*"),
 					hb.Button().
-						HTML("See Image Data").
+						HTML(crud.t("See Image Data")).
 						Attr("v-on:click", "tmp.show_url_"+fieldName+" = !tmp.show_url_"+fieldName),
 					hb.TextArea().
 						Type(hb.TYPE_URL).
@@ -346,6 +384,83 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 					formGroupInput.AddChild(option)
 				}
 			}
+			if hasDependentOptions(field) {
+				formGroupInput.AddChild(dependentOptionsTag(field))
+			}
+		}
+
+		if field.GetType() == FORM_FIELD_TYPE_MULTISELECT {
+			formGroupInput = hb.Select().Class("form-select").Attr("multiple", "multiple").Attr("v-model", "entityModel."+fieldName)
+			for _, opt := range fieldOptions(field) {
+				option := hb.Option().Value(opt.Key).Text(opt.Value)
+				formGroupInput.AddChild(option)
+			}
+			if hasDependentOptions(field) {
+				formGroupInput.AddChild(dependentOptionsTag(field))
+			}
+		}
+
+		if field.GetType() == FORM_FIELD_TYPE_CHECKBOXES || field.GetType() == FORM_FIELD_TYPE_RADIO {
+			inputType := lo.Ternary(field.GetType() == FORM_FIELD_TYPE_RADIO, hb.TYPE_RADIO, hb.TYPE_CHECKBOX)
+			formGroupInput = hb.Div()
+			for index, opt := range fieldOptions(field) {
+				optionID := fieldID + "_" + utils.ToString(index)
+				formGroupInput.AddChild(hb.Div().Class("form-check").Children([]hb.TagInterface{
+					hb.Input().
+						Type(inputType).
+						Class("form-check-input").
+						ID(optionID).
+						Value(opt.Key).
+						Attr("v-model", "entityModel."+fieldName),
+					hb.Label().
+						Class("form-check-label").
+						Attr("for", optionID).
+						Text(opt.Value),
+				}))
+			}
+		}
+
+		if isBoolean(field) {
+			checkboxID := fieldID + "_input"
+			formGroupInput = hb.Div().
+				Class("form-check").
+				ClassIf(field.GetType() == FORM_FIELD_TYPE_SWITCH, "form-switch").
+				Children([]hb.TagInterface{
+					hb.Input().
+						Type(hb.TYPE_CHECKBOX).
+						Class("form-check-input").
+						ID(checkboxID).
+						Attr("true-value", "1").
+						Attr("false-value", "0").
+						Attr("v-model", "entityModel."+fieldName),
+					hb.Label().
+						Class("form-check-label").
+						Attr("for", checkboxID).
+						Text(fieldLabel).
+						Child(requiredMarker(field)),
+				})
+		}
+
+		if field.GetType() == FORM_FIELD_TYPE_TAGS {
+			formGroupInput = hb.Div().Children([]hb.TagInterface{
+				hb.Div().Class("mb-1").Child(
+					hb.Span().
+						Class("badge bg-secondary me-1").
+						Attr("v-for", "(tag, index) in entityModel."+fieldName).
+						Attr("v-bind:key", "tag").
+						Child(hb.Span().Text("{{ tag }}")).
+						Child(hb.Button().
+							Type(hb.TYPE_BUTTON).
+							Class("btn-close btn-close-white ms-1").
+							Style("font-size:0.6rem;").
+							Attr("v-on:click", "tagRemove('"+fieldName+"', index)")),
+				),
+				hb.Input().
+					Type(hb.TYPE_TEXT).
+					Class("form-control").
+					Attr("placeholder", crud.t("Type and press Enter")).
+					Attr("v-on:keydown.enter.prevent", "tagAdd('"+fieldName+"', $event)"),
+			})
 		}
 
 		if field.GetType() == FORM_FIELD_TYPE_TEXTAREA {
@@ -361,7 +476,7 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 		}
 
 		formGroupInput.ID(fieldID)
-		if field.GetType() != FORM_FIELD_TYPE_RAW {
+		if field.GetType() != FORM_FIELD_TYPE_RAW && !isBoolean(field) {
 			formGroup.AddChild(formGroupLabel)
 		}
 		formGroup.AddChild(formGroupInput)
@@ -372,6 +487,11 @@ func (crud *Crud) form(fields []form.FieldInterface) []hb.TagInterface {
 			formGroup.AddChild(formGroupHelp)
 		}
 
+		if conditional, ok := conditionalField(field); ok && len(conditional.ShowIf) > 0 {
+			showIf, _ := utils.ToJSON(conditional.ShowIf)
+			formGroup.Attr("v-show", "conditionsMatch("+showIf+")")
+		}
+
 		tags = append(tags, formGroup)
 
 		if field.GetType() == FORM_FIELD_TYPE_BLOCKAREA {
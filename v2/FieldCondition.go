@@ -0,0 +1,22 @@
+package crud
+
+// FieldCondition is a condition on the value of another field of the
+// form, used for showing fields and making them required.
+//
+// Example:
+//
+//	crud.FieldCondition{Field: "type", Operator: crud.CONDITION_OPERATOR_EQUALS, Value: "business"}
+type FieldCondition struct {
+	// Field is the name of the field the condition is checked against
+	Field string
+
+	// Operator is one of the CONDITION_OPERATOR_* constants,
+	// defaults to CONDITION_OPERATOR_EQUALS
+	Operator string
+
+	// Value is the value compared by the equals operators
+	Value string
+
+	// Values are the values compared by the in operators
+	Values []string
+}
@@ -1,6 +1,12 @@
 package crud
 
-import "errors"
+import (
+	"errors"
+	"net/url"
+
+	"github.com/gouniverse/form"
+	"github.com/samber/lo"
+)
 
 func New(config Config) (crud Crud, err error) {
 	if config.FuncRows == nil {
@@ -25,7 +31,9 @@ func New(config Config) (crud Crud, err error) {
 	crud.entityNameSingular = config.EntityNameSingular
 	crud.fileManagerURL = config.FileManagerURL
 	crud.funcCreate = config.FuncCreate
+	crud.funcCreateDefaults = config.FuncCreateDefaults
 	crud.funcReadExtras = config.FuncReadExtras
+	crud.funcLocale = config.FuncLocale
 	crud.funcFetchReadData = config.FuncFetchReadData
 	crud.funcFetchUpdateData = config.FuncFetchUpdateData
 	crud.funcLayout = config.FuncLayout
@@ -33,8 +41,22 @@ func New(config Config) (crud Crud, err error) {
 	crud.funcTrash = config.FuncTrash
 	crud.funcUpdate = config.FuncUpdate
 	crud.homeURL = config.HomeURL
+	crud.pathRouting = config.PathRouting
 	crud.readFields = config.ReadFields
+	crud.translator = config.Translator
 	crud.updateFields = config.UpdateFields
 
+	// the dependent options of the htmx create form are reloaded from the
+	// options route, set on copies as the fields may be shared by Cruds
+	crud.createFields = lo.Map(crud.createFields, func(field form.FieldInterface, _ int) form.FieldInterface {
+		conditional, ok := conditionalField(field)
+		if !ok {
+			return field
+		}
+		copied := *conditional
+		copied.optionsURL = crud.UrlEntityOptionsAjax() + "&form=create&field=" + url.QueryEscape(field.GetName())
+		return &copied
+	})
+
 	return crud, err
 }
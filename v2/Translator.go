@@ -0,0 +1,10 @@
+package crud
+
+// Translator translates the strings of the screens, the texts of the
+// buttons, the messages and the labels, help and options of the fields.
+// The keys are the English strings, e.g. "Save" or "New {name}".
+type Translator interface {
+	// Translate returns the message of the key in the locale, with the
+	// {name} placeholders replaced by the params, or else the key
+	Translate(locale string, key string, params map[string]string) string
+}
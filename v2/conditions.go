@@ -0,0 +1,243 @@
+package crud
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gouniverse/form"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// conditionsMatch returns true if all the conditions match the values
+// of the form. The values of multi-valued fields match if any of the
+// selected values matches.
+//
+// Parameters:
+// - conditions: the conditions to check
+// - values: the values of the form, keyed by field name
+//
+// Returns:
+// - bool - true if all the conditions match, or there are no conditions
+func conditionsMatch(conditions []FieldCondition, values map[string]string) bool {
+	return lo.EveryBy(conditions, func(condition FieldCondition) bool {
+		return conditionMatch(condition, values[condition.Field])
+	})
+}
+
+// conditionMatch returns true if the condition matches the value
+func conditionMatch(condition FieldCondition, value string) bool {
+	selected := DecodeMultiValue(value)
+	isEmpty := len(lo.Compact(selected)) == 0
+
+	switch condition.Operator {
+	case CONDITION_OPERATOR_NOT_EQUALS:
+		return !lo.Contains(selected, condition.Value)
+	case CONDITION_OPERATOR_IN:
+		return len(lo.Intersect(selected, condition.Values)) > 0
+	case CONDITION_OPERATOR_NOT_IN:
+		return len(lo.Intersect(selected, condition.Values)) == 0
+	case CONDITION_OPERATOR_EMPTY:
+		return isEmpty
+	case CONDITION_OPERATOR_NOT_EMPTY:
+		return !isEmpty
+	}
+
+	return lo.Contains(selected, condition.Value)
+}
+
+// conditionalField returns the field as a ConditionalField,
+// if it is one
+func conditionalField(field form.FieldInterface) (*ConditionalField, bool) {
+	conditional, ok := field.(*ConditionalField)
+	return conditional, ok
+}
+
+// isFieldVisible returns true if the field is shown for the values of the form
+func isFieldVisible(field form.FieldInterface, values map[string]string) bool {
+	conditional, ok := conditionalField(field)
+	return !ok || conditionsMatch(conditional.ShowIf, values)
+}
+
+// isFieldRequired returns true if the field must be filled in for
+// the values of the form. Hidden fields are never required
+func isFieldRequired(field form.FieldInterface, values map[string]string) bool {
+	if !isFieldVisible(field, values) {
+		return false
+	}
+
+	if field.GetRequired() {
+		return true
+	}
+
+	conditional, ok := conditionalField(field)
+	return ok && len(conditional.RequiredIf) > 0 && conditionsMatch(conditional.RequiredIf, values)
+}
+
+// hasDependentOptions returns true if the options of the field
+// depend on the values of other fields
+func hasDependentOptions(field form.FieldInterface) bool {
+	conditional, ok := conditionalField(field)
+	return ok && conditional.DependentOptionsF != nil
+}
+
+// requiredFieldsError checks the required fields against the posted
+// values, skipping the fields hidden by their conditions.
+//
+// Parameters:
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+//
+// Returns:
+// - string - the error message, or an empty string if all is filled in
+func (crud *Crud) requiredFieldsError(fields []form.FieldInterface, posts map[string]string) string {
+	for _, field := range fields {
+		if field.GetName() == "" || !isFieldRequired(field, posts) {
+			continue
+		}
+
+		if _, exists := posts[field.GetName()]; !exists {
+			return crud.t("{label} is required field", "label", crud.t(field.GetLabel()))
+		}
+
+		if isEmptyValue(field, posts[field.GetName()]) {
+			return crud.t("{label} is required field", "label", crud.t(field.GetLabel()))
+		}
+	}
+
+	return ""
+}
+
+// dependentOptionsError checks the posted values of the fields with
+// dependent options are options for the posted values, e.g. not a
+// region of another country, skipping the fields hidden by their
+// conditions.
+//
+// Parameters:
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+//
+// Returns:
+// - string - the error message, or an empty string if all are options
+func (crud *Crud) dependentOptionsError(fields []form.FieldInterface, posts map[string]string) string {
+	for _, field := range fields {
+		value, exists := posts[field.GetName()]
+		if !exists || !hasDependentOptions(field) || !isFieldVisible(field, posts) {
+			continue
+		}
+
+		selected := lo.Ternary(isMultiValued(field), DecodeMultiValue(value), []string{value})
+
+		keys := lo.Map(dependentFieldOptions(field, posts), func(option form.FieldOption, _ int) string {
+			return option.Key
+		})
+
+		if len(lo.Without(lo.Compact(selected), keys...)) > 0 {
+			return crud.t("{label} has an option which is not available", "label", crud.t(field.GetLabel()))
+		}
+	}
+
+	return ""
+}
+
+// dependentFieldOptions returns all the options of the field for
+// the values of the form, the static ones followed by the dependent ones
+func dependentFieldOptions(field form.FieldInterface, values map[string]string) []form.FieldOption {
+	options := fieldOptions(field)
+
+	if conditional, ok := conditionalField(field); ok && conditional.DependentOptionsF != nil {
+		options = append(options, conditional.DependentOptionsF(values)...)
+	}
+
+	return options
+}
+
+// dependentOptions returns the dependent options of the fields for
+// the values of the form, keyed as options_<name> in the temporary
+// state of the Vue form
+func (crud *Crud) dependentOptions(fields []form.FieldInterface, values map[string]string) map[string]any {
+	state := map[string]any{}
+
+	for _, field := range fields {
+		conditional, ok := conditionalField(field)
+		if !ok || conditional.DependentOptionsF == nil {
+			continue
+		}
+
+		state["options_"+field.GetName()] = optionsJSON(crud.translateOptions(conditional.DependentOptionsF(values)))
+	}
+
+	return state
+}
+
+// dependentOptionsConfig returns the configuration of the fields with
+// dependent options, used by the Vue forms to reload the options when
+// the fields they depend on change
+func (crud *Crud) dependentOptionsConfig(fields []form.FieldInterface, formName string) []map[string]any {
+	configs := []map[string]any{}
+
+	for _, field := range fields {
+		conditional, ok := conditionalField(field)
+		if !ok || conditional.DependentOptionsF == nil || len(conditional.DependsOn) == 0 {
+			continue
+		}
+
+		configs = append(configs, map[string]any{
+			"field":     field.GetName(),
+			"dependsOn": conditional.DependsOn,
+			"url":       crud.UrlEntityOptionsAjax() + "&form=" + url.QueryEscape(formName) + "&field=" + url.QueryEscape(field.GetName()) + "&dependent=1",
+		})
+	}
+
+	return configs
+}
+
+// optionsJSON converts the options to the format used by the forms
+func optionsJSON(options []form.FieldOption) []map[string]string {
+	return lo.Map(options, func(option form.FieldOption, _ int) map[string]string {
+		return map[string]string{"key": option.Key, "value": option.Value}
+	})
+}
+
+// formValues reads the posted values of all the named fields
+func formValues(r *http.Request, fields []form.FieldInterface) map[string]string {
+	values := map[string]string{}
+
+	for _, field := range fields {
+		if field.GetName() == "" {
+			continue
+		}
+		values[field.GetName()] = requestValue(r, field)
+	}
+
+	return values
+}
+
+// requiredMarker returns the asterisk shown next to the label of
+// a required field, toggled by the RequiredIf conditions if set
+func requiredMarker(field form.FieldInterface) hb.TagInterface {
+	marker := hb.Sup().Text("*").Class("text-danger ml-1")
+
+	if field.GetRequired() {
+		return marker
+	}
+
+	conditional, ok := conditionalField(field)
+	if !ok || len(conditional.RequiredIf) == 0 {
+		return nil
+	}
+
+	requiredIf, _ := utils.ToJSON(conditional.RequiredIf)
+	return marker.Attr("v-if", "conditionsMatch("+requiredIf+")")
+}
+
+// dependentOptionsTag returns the options of a select loaded
+// for the current values of the Vue form
+func dependentOptionsTag(field form.FieldInterface) hb.TagInterface {
+	return hb.Option().
+		Attr("v-for", "option in tmp.options_"+field.GetName()).
+		Attr("v-bind:key", "option.key").
+		Attr("v-bind:value", "option.key").
+		Text("{{ option.value }}")
+}
@@ -4,6 +4,7 @@ const pathHome = "home"
 const pathEntityCreateAjax = "entity-create-ajax"
 const pathEntityCreateModal = "entity-create-modal"
 const pathEntityManager = "entity-manager"
+const pathEntityOptionsAjax = "entity-options-ajax"
 const pathEntityRead = "entity-read"
 const pathEntityUpdate = "entity-update"
 const pathEntityUpdateAjax = "entity-update-ajax"
@@ -20,3 +21,16 @@ const FORM_FIELD_TYPE_BLOCKAREA = "blockarea"
 const FORM_FIELD_TYPE_DATETIME = "datetime"
 const FORM_FIELD_TYPE_PASSWORD = "password"
 const FORM_FIELD_TYPE_RAW = "raw"
+const FORM_FIELD_TYPE_MULTISELECT = "multiselect"
+const FORM_FIELD_TYPE_CHECKBOXES = "checkboxes"
+const FORM_FIELD_TYPE_RADIO = "radio"
+const FORM_FIELD_TYPE_CHECKBOX = "checkbox"
+const FORM_FIELD_TYPE_SWITCH = "switch"
+const FORM_FIELD_TYPE_TAGS = "tags"
+
+const CONDITION_OPERATOR_EQUALS = "eq"
+const CONDITION_OPERATOR_NOT_EQUALS = "neq"
+const CONDITION_OPERATOR_IN = "in"
+const CONDITION_OPERATOR_NOT_IN = "not_in"
+const CONDITION_OPERATOR_EMPTY = "empty"
+const CONDITION_OPERATOR_NOT_EMPTY = "not_empty"
@@ -0,0 +1,81 @@
+package crud
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/gouniverse/form"
+	"github.com/samber/lo"
+)
+
+// createValues returns the initial values of the create form. These are
+// the values of the fields, overridden by the ones returned by
+// FuncCreateDefaults.
+//
+// Parameters:
+// - r: the HTTP request
+// - prefill: whether the values in the query of the request, keyed by
+// field name, override the defaults
+//
+// Returns:
+// - map[string]string - the values, keyed by field name
+func (crud *Crud) createValues(r *http.Request, prefill bool) map[string]string {
+	values := map[string]string{}
+
+	lo.ForEach(crud.createFields, func(field form.FieldInterface, index int) {
+		values[field.GetName()] = field.GetValue()
+	})
+
+	if crud.funcCreateDefaults != nil {
+		for name, value := range crud.funcCreateDefaults(r) {
+			values[name] = value
+		}
+	}
+
+	if !prefill {
+		return values
+	}
+
+	for name, value := range crud.prefillValues(r) {
+		values[name] = value
+	}
+
+	return values
+}
+
+// prefillValues returns the values of the create fields found in the
+// query of the request
+func (crud *Crud) prefillValues(r *http.Request) map[string]string {
+	query := r.URL.Query()
+	values := map[string]string{}
+
+	for _, field := range crud.createFields {
+		name := field.GetName()
+
+		if name == "" || (!query.Has(name) && !query.Has(name+"[]")) {
+			continue
+		}
+
+		values[name] = requestValue(r, field)
+	}
+
+	return values
+}
+
+// isCreateRequested returns true if the create modal should be opened
+// when the entity manager is loaded, e.g. from a link of UrlEntityCreate
+func (crud *Crud) isCreateRequested(r *http.Request) bool {
+	return crud.funcCreate != nil && r.URL.Query().Get("create") == "1"
+}
+
+// encodeQuery encodes the values as URL query parameters,
+// sorted by name, e.g. "a=1&b=2"
+func encodeQuery(values map[string]string) string {
+	names := lo.Keys(values)
+	sort.Strings(names)
+
+	return lo.Reduce(names, func(query string, name string, index int) string {
+		return query + lo.Ternary(index == 0, "", "&") + url.QueryEscape(name) + "=" + url.QueryEscape(values[name])
+	}, "")
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/gouniverse/form"
 	"github.com/gouniverse/hb"
 	"github.com/gouniverse/utils"
-	"github.com/samber/lo"
 )
 
 type entityCreateController struct {
@@ -21,7 +20,7 @@ func (crud *Crud) newEntityCreateController() *entityCreateController {
 }
 
 func (controller *entityCreateController) modalShow(w http.ResponseWriter, r *http.Request) {
-	w.Write([]byte(controller.modal().ToHTML()))
+	w.Write([]byte(controller.modal(controller.crud.createValues(r, true)).ToHTML()))
 }
 
 func (controller *entityCreateController) modalSave(w http.ResponseWriter, r *http.Request) {
@@ -29,55 +28,48 @@ func (controller *entityCreateController) modalSave(w http.ResponseWriter, r *ht
 
 	posts := map[string]string{}
 	for _, name := range names {
-		posts[name] = utils.Req(r, name, "")
+		field, _ := fieldByName(controller.crud.createFields, name)
+		posts[name] = requestValue(r, field)
 	}
 
-	// Check required fields
-	for _, field := range controller.crud.createFields {
-		if !field.GetRequired() {
-			continue
-		}
-
-		if _, exists := posts[field.GetName()]; !exists {
-			errorMessage := field.GetLabel() + " is required field"
-			response := hb.Swal(hb.SwalOptions{Icon: "error", Text: errorMessage}).ToHTML()
-			w.Write([]byte(response))
-			return
-		}
-
-		if lo.IsEmpty(posts[field.GetName()]) {
-			errorMessage := field.GetLabel() + " is required field"
-			response := hb.Swal(hb.SwalOptions{Icon: "error", Text: errorMessage}).ToHTML()
-			w.Write([]byte(response))
-			return
-		}
+	// Check required fields, skipping the hidden ones
+	if errorMessage := controller.crud.requiredFieldsError(controller.crud.createFields, posts); errorMessage != "" {
+		response := hb.Swal(hb.SwalOptions{Icon: "error", Text: errorMessage}).ToHTML()
+		w.Write([]byte(response))
+		return
+	}
+
+	if errorMessage := controller.crud.dependentOptionsError(controller.crud.createFields, posts); errorMessage != "" {
+		response := hb.Swal(hb.SwalOptions{Icon: "error", Text: errorMessage}).ToHTML()
+		w.Write([]byte(response))
+		return
 	}
 
 	entityID, err := controller.crud.funcCreate(posts)
 
 	if err != nil {
-		errorMessage := "Save failed: " + err.Error()
+		errorMessage := controller.crud.t("Save failed: {error}", "error", err.Error())
 		response := hb.Swal(hb.SwalOptions{Icon: "error", Text: errorMessage}).ToHTML()
 		w.Write([]byte(response))
 		return
 	}
 
-	redirectURL := controller.crud.UrlEntityUpdate() + "?entity_id=" + entityID
-	successMessage := "Saved successfully"
+	redirectURL, _ := utils.ToJSON(controller.crud.UrlEntityUpdateByID(entityID))
+	successMessage := controller.crud.t("Saved successfully")
 	response := hb.Wrap().
 		Child(hb.Swal(hb.SwalOptions{
 			Icon: "success",
 			Text: successMessage,
 		})).
-		Child(hb.Script("setTimeout(() => {window.location.href = '" + redirectURL + "'}, 2000)")).
+		Child(hb.Script("setTimeout(() => {window.location.href = " + redirectURL + "}, 2000)")).
 		ToHTML()
 
 	w.Write([]byte(response))
 }
 
-func (controller *entityCreateController) modal() hb.TagInterface {
+func (controller *entityCreateController) modal(values map[string]string) hb.TagInterface {
 	form := form.NewForm(form.FormOptions{
-		Fields: controller.crud.createFields,
+		Fields: controller.crud.translateFields(controller.crud.createFields),
 	}).Build()
 
 	//controller.crud.form(controller.crud.createFields)
@@ -85,12 +77,13 @@ func (controller *entityCreateController) modal() hb.TagInterface {
 	submitUrl := controller.crud.UrlEntityCreateAjax()
 
 	modalID := "ModalEntityCreate"
+	jsonValues, _ := utils.ToJSON(values)
 	modalBackdropClass := "ModalBackdrop"
 
 	modalCloseScript := `closeModal` + modalID + `();`
 
 	modalHeading := hb.Heading5().
-		Text("New " + controller.crud.entityNameSingular).
+		Text(controller.crud.tEntity("New {name}")).
 		Style(`margin:0px;`)
 
 	modalClose := hb.Button().Type("button").
@@ -102,7 +95,7 @@ func (controller *entityCreateController) modal() hb.TagInterface {
 
 	buttonSubmit := hb.Button().
 		Child(hb.I().Class("bi bi-check me-2")).
-		HTML("Create & Edit").
+		HTML(controller.crud.t("Create & Edit")).
 		Class("btn btn-primary float-end").
 		HxInclude("#" + modalID).
 		HxPost(submitUrl).
@@ -112,7 +105,7 @@ func (controller *entityCreateController) modal() hb.TagInterface {
 
 	buttonCancel := hb.Button().
 		Child(hb.I().Class("bi bi-chevron-left me-2")).
-		HTML("Close").
+		HTML(controller.crud.t("Close")).
 		Class("btn btn-secondary float-start").
 		Data("bs-dismiss", "modal").
 		OnClick(modalCloseScript)
@@ -122,6 +115,8 @@ func (controller *entityCreateController) modal() hb.TagInterface {
 		Class("fade show").
 		Style(`display:block;position:fixed;top:50%;left:50%;transform:translate(-50%,-50%);z-index:1051;`).
 		Child(hb.Script(jsCloseFn)).
+		Child(hb.Script(scriptFormHelpers + scriptConditions + `crudFormFill(document.getElementById('` + modalID + `'), ` + jsonValues + `);` +
+			`crudConditionsInit(document.getElementById('` + modalID + `'));`)).
 		Child(bs.ModalDialog().
 			Child(bs.ModalContent().
 				Child(
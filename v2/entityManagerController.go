@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/gouniverse/cdn"
-	"github.com/gouniverse/form"
 	"github.com/gouniverse/hb"
 	"github.com/gouniverse/icons"
 	"github.com/gouniverse/utils"
@@ -26,11 +25,11 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 	// header := cms.cmsHeader(endpoint)
 	breadcrumbs := controller.crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: controller.crud.t("Home"),
 			URL:  controller.crud.urlHome(),
 		},
 		{
-			Name: controller.crud.entityNameSingular + " Manager",
+			Name: controller.crud.tEntity("{name} Manager"),
 			URL:  controller.crud.UrlEntityManager(),
 		},
 	})
@@ -41,17 +40,25 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 	// 	AddChild(icons.Icon("bi-plus-circle", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
 	// 	HTML("New " + crud.entityNameSingular)
 
+	// the values to prefill the create modal with are passed on to it
+	prefill := controller.crud.prefillValues(r)
+	createModalURL := controller.crud.UrlEntityCreateModal() + lo.Ternary(len(prefill) == 0, "", "&"+encodeQuery(prefill))
+
 	buttonCreate := hb.Button().
 		Class("btn btn-success float-end").
 		// Attr("v-on:click", "showEntityCreateModal").
 		AddChild(icons.Icon("bi-plus-circle", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("New " + controller.crud.entityNameSingular).
-		HxGet(controller.crud.UrlEntityCreateModal()).
+		HTML(controller.crud.tEntity("New {name}")).
+		HxGet(createModalURL).
 		HxTarget("body").
 		HxSwap("beforeend")
 
+	if controller.crud.isCreateRequested(r) {
+		buttonCreate.HxTrigger("click, load")
+	}
+
 	heading := hb.Heading1().
-		HTML(controller.crud.entityNameSingular + " Manager").
+		HTML(controller.crud.tEntity("{name} Manager")).
 		Child(buttonCreate)
 
 	rows, errRows := controller.crud.funcRows()
@@ -59,7 +66,7 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 	tableContent := lo.IfF(errRows != nil, func() hb.TagInterface {
 		alert := hb.Div().
 			Class("alert alert-danger").
-			HTML("There was an error retrieving the data. Please try again later")
+			HTML(controller.crud.t("There was an error retrieving the data. Please try again later"))
 
 		return alert
 	}).ElseF(func() hb.TagInterface {
@@ -73,10 +80,10 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 							Children(lo.Map(controller.crud.columnNames, func(columnName string, _ int) hb.TagInterface {
 								columnName = strings.ReplaceAll(columnName, "{!!", "")
 								columnName = strings.ReplaceAll(columnName, "!!}", "")
-								return hb.TH().Text(columnName)
+								return hb.TH().Text(controller.crud.t(columnName))
 							})).
 							Child(hb.TD().
-								HTML("Actions").
+								HTML(controller.crud.t("Actions")).
 								Style("width:120px;")),
 					})).
 			Child(
@@ -86,24 +93,24 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 							Class("btn btn-sm btn-outline-info").
 							Child(icons.Icon("bi-eye", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Show").
-							Href(controller.crud.UrlEntityRead() + "&entity_id=" + row.ID).
+							Attr("title", controller.crud.t("Show")).
+							Href(controller.crud.UrlEntityReadByID(row.ID)).
 							Style("margin-right:5px")
 
 						buttonEdit := hb.Hyperlink().
 							Class("btn btn-sm btn-outline-warning").
 							Child(icons.Icon("bi-pencil-square", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Edit").
+							Attr("title", controller.crud.t("Edit")).
 							Attr("type", "button").
-							Href(controller.crud.UrlEntityUpdate() + "&entity_id=" + row.ID).
+							Href(controller.crud.UrlEntityUpdateByID(row.ID)).
 							Style("margin-right:5px")
 
 						buttonTrash := hb.Button().
 							Class("btn btn-sm btn-outline-danger").
 							Child(icons.Icon("bi-trash", 18, 18, "#333").
 								Style("margin-top:-4px;")).
-							Attr("title", "Trash").
+							Attr("title", controller.crud.t("Trash")).
 							Attr("type", "button").
 							Attr("v-on:click", "showEntityTrashModal('"+row.ID+"')")
 
@@ -144,11 +151,8 @@ func (controller *entityManagerController) page(w http.ResponseWriter, r *http.R
 	urlEntityTrashAjax, _ := utils.ToJSON(controller.crud.UrlEntityTrashAjax())
 	urlEntityUpdate, _ := utils.ToJSON(controller.crud.UrlEntityUpdate())
 
-	customAttrValues := map[string]string{}
-	lo.ForEach(controller.crud.createFields, func(field form.FieldInterface, index int) {
-		customAttrValues[field.GetName()] = field.GetValue()
-	})
-	jsonCustomValues, _ := utils.ToJSON(customAttrValues)
+	customAttrValues := controller.crud.createValues(r, true)
+	jsonCustomValues, _ := utils.ToJSON(formModel(controller.crud.createFields, customAttrValues))
 
 	inlineScript := `
 const entityCreateUrl = ` + urlEntityCreateAjax + `;
@@ -197,9 +201,9 @@ const EntityManager = {
 					return location.href = entityUpdateUrl+ "&entity_id=" + result.data.entity_id;
 				}
 				
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result.message});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result.message});
 			}).fail((result)=>{
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 			});
 		},
 
@@ -210,22 +214,22 @@ const EntityManager = {
 				entity_id:entityId
 			}).done((response)=>{
 				if (response.status !== "success") {
-					return Swal.fire({icon: 'error', title: 'Oops...', text: result.message});
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result.message});
 				}
 
 				setTimeout(()=>{return location.href = location.href;}, 3000)
 
-				return Swal.fire({icon: 'success', title: 'Entity trashed'});
+				return Swal.fire({icon: 'success', title: crudT('Entity trashed')});
 			}).fail((result)=>{
 				console.log(result);
-				return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+				return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 			});
 		}
 	}
 };
 Vue.createApp(EntityManager).mount('#entity-manager')
 	`
-	title := controller.crud.entityNameSingular + " Manager"
+	title := controller.crud.tEntity("{name} Manager")
 	html := controller.crud.layout(w, r, title, content, []string{
 		cdn.JqueryDataTablesCss_1_13_4(),
 	}, "html{width:100%;}", []string{
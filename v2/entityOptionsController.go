@@ -0,0 +1,45 @@
+package crud
+
+import (
+	"net/http"
+
+	"github.com/gouniverse/api"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+type entityOptionsController struct {
+	crud *Crud
+}
+
+func (crud *Crud) newEntityOptionsController() *entityOptionsController {
+	return &entityOptionsController{
+		crud: crud,
+	}
+}
+
+// pageOptionsAjax returns the options of a field with dependent options
+// for the posted values of the form. With dependent=1 only the dependent
+// options are returned (as used by the Vue forms), otherwise all of them
+func (controller *entityOptionsController) pageOptionsAjax(w http.ResponseWriter, r *http.Request) {
+	fields := lo.Ternary(utils.Req(r, "form", "") == "update", controller.crud.updateFields, controller.crud.createFields)
+	field, found := fieldByName(fields, utils.Req(r, "field", ""))
+
+	if !found || !hasDependentOptions(field) {
+		api.Respond(w, r, api.Error(controller.crud.t("Field not found")))
+		return
+	}
+
+	values := formValues(r, fields)
+	conditional, _ := conditionalField(field)
+
+	options := lo.TernaryF(utils.Req(r, "dependent", "") == "1", func() []map[string]string {
+		return optionsJSON(controller.crud.translateOptions(conditional.DependentOptionsF(values)))
+	}, func() []map[string]string {
+		return optionsJSON(controller.crud.translateOptions(dependentFieldOptions(field, values)))
+	})
+
+	api.Respond(w, r, api.SuccessWithData(controller.crud.t("Options fetched successfully"), map[string]interface{}{
+		"options": options,
+	}))
+}
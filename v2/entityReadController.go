@@ -24,7 +24,7 @@ func (crud *Crud) newEntityReadController() *entityReadController {
 func (controller *entityReadController) page(w http.ResponseWriter, r *http.Request) {
 	entityID := utils.Req(r, "entity_id", "")
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(controller.crud.t("Entity ID is required")))
 		return
 	}
 
@@ -35,33 +35,33 @@ func (controller *entityReadController) page(w http.ResponseWriter, r *http.Requ
 
 	breadcrumbs := controller.crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: controller.crud.t("Home"),
 			URL:  controller.crud.urlHome(),
 		},
 		{
-			Name: controller.crud.entityNameSingular + " Manager",
+			Name: controller.crud.tEntity("{name} Manager"),
 			URL:  controller.crud.UrlEntityManager(),
 		},
 		{
-			Name: "View " + controller.crud.entityNameSingular,
-			URL:  controller.crud.UrlEntityUpdate() + "&entity_id=" + entityID,
+			Name: controller.crud.tEntity("View {name}"),
+			URL:  controller.crud.UrlEntityUpdateByID(entityID),
 		},
 	})
 
 	buttonEdit := hb.Hyperlink().
 		Class("btn btn-primary ml-2 float-end").
 		Child(icons.Icon("bi-pencil-square", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Edit").
-		Href(controller.crud.UrlEntityUpdate() + "&entity_id=" + entityID)
+		HTML(controller.crud.t("Edit")).
+		Href(controller.crud.UrlEntityUpdateByID(entityID))
 
 	buttonCancel := hb.Hyperlink().
 		Class("btn btn-secondary ml-2 float-end").
 		Child(icons.Icon("bi-chevron-left", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Back").
+		HTML(controller.crud.t("Back")).
 		Href(controller.crud.UrlEntityManager())
 
 	heading := hb.Heading1().
-		HTML("View " + controller.crud.entityNameSingular).
+		HTML(controller.crud.tEntity("View {name}")).
 		Child(buttonEdit).
 		Child(buttonCancel)
 
@@ -76,7 +76,7 @@ func (controller *entityReadController) page(w http.ResponseWriter, r *http.Requ
 	table := lo.IfF(err != nil, func() hb.TagInterface {
 		alert := hb.Div().
 			Class("alert alert-danger").
-			HTML("There was an error retrieving the data. Please try again later")
+			HTML(controller.crud.t("There was an error retrieving the data. Please try again later"))
 
 		return alert
 	}).ElseF(func() hb.TagInterface {
@@ -127,7 +127,7 @@ func (controller *entityReadController) page(w http.ResponseWriter, r *http.Requ
 		container.Children(controller.crud.funcReadExtras(entityID))
 	}
 	content := container.ToHTML()
-	title := "View " + controller.crud.entityNameSingular
+	title := controller.crud.tEntity("View {name}")
 	html := controller.crud.layout(w, r, title, content, []string{}, "", []string{}, "")
 
 	w.WriteHeader(200)
@@ -9,7 +9,6 @@ import (
 	"github.com/gouniverse/hb"
 	"github.com/gouniverse/icons"
 	"github.com/gouniverse/utils"
-	"github.com/samber/lo"
 )
 
 type entityUpdateController struct {
@@ -25,33 +24,33 @@ func (crud *Crud) newEntityUpdateController() *entityUpdateController {
 func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Request) {
 	entityID := utils.Req(r, "entity_id", "")
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(controller.crud.t("Entity ID is required")))
 		return
 	}
 
 	breadcrumbs := controller.crud._breadcrumbs([]Breadcrumb{
 		{
-			Name: "Home",
+			Name: controller.crud.t("Home"),
 			URL:  controller.crud.urlHome(),
 		},
 		{
-			Name: controller.crud.entityNameSingular + " Manager",
+			Name: controller.crud.tEntity("{name} Manager"),
 			URL:  controller.crud.UrlEntityManager(),
 		},
 		{
-			Name: "Edit " + controller.crud.entityNameSingular,
-			URL:  controller.crud.UrlEntityUpdate() + "&entity_id=" + entityID,
+			Name: controller.crud.tEntity("Edit {name}"),
+			URL:  controller.crud.UrlEntityUpdateByID(entityID),
 		},
 	})
 
 	buttonSave := hb.Button().Class("btn btn-success float-end").Attr("v-on:click", "entitySave(true)").
 		AddChild(icons.Icon("bi-check-all", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Save")
+		HTML(controller.crud.t("Save"))
 	buttonApply := hb.Button().Class("btn btn-success float-end").Attr("v-on:click", "entitySave").
 		Style("margin-right:10px;").
 		AddChild(icons.Icon("bi-check", 16, 16, "white").Style("margin-top:-4px;margin-right:8px;")).
-		HTML("Apply")
-	heading := hb.Heading1().Text("Edit " + controller.crud.entityNameSingular).
+		HTML(controller.crud.t("Apply"))
+	heading := hb.Heading1().Text(controller.crud.tEntity("Edit {name}")).
 		AddChild(buttonSave).
 		AddChild(buttonApply)
 
@@ -63,7 +62,7 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 	customAttrValues, errData := controller.crud.funcFetchUpdateData(entityID)
 
 	if errData != nil {
-		api.Respond(w, r, api.Error("Fetch data failed"))
+		api.Respond(w, r, api.Error(controller.crud.t("Fetch data failed")))
 		return
 	}
 
@@ -71,18 +70,22 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 
 	content := container.ToHTML()
 
-	jsonCustomValues, _ := utils.ToJSON(customAttrValues)
+	jsonCustomValues, _ := utils.ToJSON(formModel(controller.crud.updateFields, customAttrValues))
+	jsonTmpValues, _ := utils.ToJSON(controller.crud.dependentOptions(controller.crud.updateFields, customAttrValues))
+	jsonDependentOptions, _ := utils.ToJSON(controller.crud.dependentOptionsConfig(controller.crud.updateFields, "update"))
 
 	urlHome, _ := utils.ToJSON(controller.crud.endpoint)
 	urlEntityTrashAjax, _ := utils.ToJSON(controller.crud.UrlEntityTrashAjax())
 	urlEntityUpdateAjax, _ := utils.ToJSON(controller.crud.UrlEntityUpdateAjax())
 
-	inlineScript := `
+	inlineScript := scriptFormHelpers + `
 	const entityManagerUrl = ` + urlHome + `;
 	const entityUpdateUrl = ` + urlEntityUpdateAjax + `;
 	const entityTrashUrl = ` + urlEntityTrashAjax + `;
 	const entityId = "` + entityID + `";
 	const customValues = ` + jsonCustomValues + `;
+	const tmpValues = ` + jsonTmpValues + `;
+	const dependentOptions = ` + jsonDependentOptions + `;
 	const EntityUpdate = {
 		data() {
 			return {
@@ -90,7 +93,9 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 					entityId,
 					...customValues
 			    },
-				tmp:{},
+				tmp:{
+					...tmpValues
+				},
 				trumbowigConfig: {
 					btns: [
 						['undo', 'redo'], 
@@ -111,16 +116,19 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 				},
 			}
 		},
-		methods: {
+		mounted(){
+			this.optionsWatch(dependentOptions);
+		},
+		methods: {` + scriptFormMethods + `
 			entitySave(redirect){
 				const entityId = this.entityModel.entityId;
 				let data = JSON.parse(JSON.stringify(this.entityModel));
 				data["entity_id"] = data["entityId"];
 				delete data["entityId"];
 
-				$.post(entityUpdateUrl, data).done((response)=>{
+				$.post(entityUpdateUrl, crudSerializeModel(data)).done((response)=>{
 					if (response.status !== "success") {
-						return Swal.fire({icon: 'error', title: 'Oops...', text: response.message});
+						return Swal.fire({icon: 'error', title: crudT('Oops...'), text: response.message});
 					}
 
 					if (redirect===true) {
@@ -129,10 +137,10 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 						}, 3000)
 					}
 
-					return Swal.fire({icon: 'success',title: 'Entity saved'});
+					return Swal.fire({icon: 'success',title: crudT('Entity saved')});
 				}).fail((result)=>{
 					console.log(result);
-					return Swal.fire({icon: 'error', title: 'Oops...', text: result});
+					return Swal.fire({icon: 'error', title: crudT('Oops...'), text: result});
 				});
 			},
 			uploadImage(event, fieldName) {
@@ -154,7 +162,7 @@ func (controller *entityUpdateController) page(w http.ResponseWriter, r *http.Re
 	// webpage := crud.webpage("Edit "+crud.entityNameSingular, h)
 	// webpage.AddScript(inlineScript)
 
-	title := "Edit " + controller.crud.entityNameSingular
+	title := controller.crud.tEntity("Edit {name}")
 	html := controller.crud.layout(w, r, title, content, []string{
 		cdn.JqueryDataTablesCss_1_13_4(),
 		cdn.TrumbowygCss_2_27_3(),
@@ -174,39 +182,34 @@ func (controller *entityUpdateController) pageSave(w http.ResponseWriter, r *htt
 	entityID := strings.Trim(utils.Req(r, "entity_id", ""), " ")
 
 	if entityID == "" {
-		api.Respond(w, r, api.Error("Entity ID is required"))
+		api.Respond(w, r, api.Error(controller.crud.t("Entity ID is required")))
 		return
 	}
 
 	names := controller.crud.listUpdateNames()
 	posts := map[string]string{}
 	for _, name := range names {
-		posts[name] = utils.Req(r, name, "")
+		field, _ := fieldByName(controller.crud.updateFields, name)
+		posts[name] = requestValue(r, field)
 	}
 
-	// Check required fields
-	for _, field := range controller.crud.updateFields {
-		if !field.GetRequired() {
-			continue
-		}
-
-		if _, exists := posts[field.GetName()]; !exists {
-			api.Respond(w, r, api.Error(field.GetLabel()+" is required field"))
-			return
-		}
+	// Check required fields, skipping the hidden ones
+	if errorMessage := controller.crud.requiredFieldsError(controller.crud.updateFields, posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
+	}
 
-		if lo.IsEmpty(posts[field.GetName()]) {
-			api.Respond(w, r, api.Error(field.GetLabel()+" is required field"))
-			return
-		}
+	if errorMessage := controller.crud.dependentOptionsError(controller.crud.updateFields, posts); errorMessage != "" {
+		api.Respond(w, r, api.Error(errorMessage))
+		return
 	}
 
 	err := controller.crud.funcUpdate(entityID, posts)
 
 	if err != nil {
-		api.Respond(w, r, api.Error("Save failed: "+err.Error()))
+		api.Respond(w, r, api.Error(controller.crud.t("Save failed: {error}", "error", err.Error())))
 		return
 	}
 
-	api.Respond(w, r, api.SuccessWithData("Saved successfully", map[string]interface{}{"entity_id": entityID}))
+	api.Respond(w, r, api.SuccessWithData(controller.crud.t("Saved successfully"), map[string]interface{}{"entity_id": entityID}))
 }
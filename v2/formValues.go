@@ -0,0 +1,159 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/form"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// isMultiValued returns true if the field holds a list of values,
+// which are encoded as a JSON array in the data maps
+func isMultiValued(field form.FieldInterface) bool {
+	return lo.Contains([]string{
+		FORM_FIELD_TYPE_MULTISELECT,
+		FORM_FIELD_TYPE_CHECKBOXES,
+		FORM_FIELD_TYPE_TAGS,
+	}, field.GetType())
+}
+
+// isBoolean returns true if the field holds a single on/off value,
+// which is stored as "1" or "0"
+func isBoolean(field form.FieldInterface) bool {
+	return field.GetType() == FORM_FIELD_TYPE_CHECKBOX ||
+		field.GetType() == FORM_FIELD_TYPE_SWITCH
+}
+
+// fieldOptions returns the static options followed by the ones
+// returned by OptionsF, if set
+func fieldOptions(field form.FieldInterface) []form.FieldOption {
+	options := append([]form.FieldOption{}, field.GetOptions()...)
+
+	if field.GetOptionsF() != nil {
+		options = append(options, field.GetOptionsF()()...)
+	}
+
+	return options
+}
+
+// requestValue reads the posted value of a field.
+//
+// Multi-valued fields accept either a JSON array (as posted by the
+// Vue forms) or repeated keys (name=a&name=b or name[]=a&name[]=b),
+// and are always returned encoded as a JSON array. Boolean fields
+// are returned as "1" or "0".
+//
+// Parameters:
+// - r: the HTTP request
+// - field: the field to read
+//
+// Returns:
+// - string - the value of the field
+func requestValue(r *http.Request, field form.FieldInterface) string {
+	name := field.GetName()
+
+	if isMultiValued(field) {
+		values := utils.ReqArray(r, name+"[]", []string{})
+
+		if len(values) == 0 {
+			values = utils.ReqArray(r, name, []string{})
+		}
+
+		if len(values) == 1 {
+			values = DecodeMultiValue(values[0])
+		}
+
+		if field.GetType() == FORM_FIELD_TYPE_TAGS {
+			values = splitTags(values)
+		}
+
+		return EncodeMultiValue(values)
+	}
+
+	if isBoolean(field) {
+		// the hidden "0" input precedes the checkbox, the last value wins
+		values := utils.ReqArray(r, name, []string{})
+		value := ""
+		if len(values) > 0 {
+			value = values[len(values)-1]
+		}
+
+		return lo.Ternary(isTruthy(value), "1", "0")
+	}
+
+	return utils.Req(r, name, "")
+}
+
+// splitTags splits comma separated tags, trims them and removes
+// the empty and duplicate ones
+func splitTags(values []string) []string {
+	tags := []string{}
+
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || lo.Contains(tags, tag) {
+				continue
+			}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// isTruthy returns true for the values posted by checked checkboxes
+func isTruthy(value string) bool {
+	return lo.Contains([]string{"1", "true", "on", "yes"}, strings.ToLower(strings.TrimSpace(value)))
+}
+
+// isEmptyValue returns true if the value of the field is considered
+// not filled in, used for checking the required fields
+func isEmptyValue(field form.FieldInterface, value string) bool {
+	if isMultiValued(field) {
+		return len(DecodeMultiValue(value)) == 0
+	}
+
+	if isBoolean(field) {
+		return value != "1"
+	}
+
+	return lo.IsEmpty(value)
+}
+
+// fieldByName returns the field with the specified name
+func fieldByName(fields []form.FieldInterface, name string) (form.FieldInterface, bool) {
+	return lo.Find(fields, func(field form.FieldInterface) bool {
+		return field.GetName() == name
+	})
+}
+
+// formModel converts the string values of the fields to the values
+// expected by the Vue form model: the multi-valued fields become
+// arrays.
+//
+// Parameters:
+// - fields: the fields of the form
+// - values: the values, keyed by field name
+//
+// Returns:
+// - map[string]any - the form model
+func formModel(fields []form.FieldInterface, values map[string]string) map[string]any {
+	model := map[string]any{}
+
+	for name, value := range values {
+		model[name] = value
+	}
+
+	for _, field := range fields {
+		if field.GetName() == "" || !isMultiValued(field) {
+			continue
+		}
+
+		model[field.GetName()] = DecodeMultiValue(values[field.GetName()])
+	}
+
+	return model
+}
@@ -11,6 +11,7 @@ require (
 	github.com/gouniverse/icons v1.3.1
 	github.com/gouniverse/utils v1.45.0
 	github.com/samber/lo v1.47.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,134 @@
+package crud
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gouniverse/form"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// scriptMessages are the keys of the messages of the scripts,
+// translated with crudT
+var scriptMessages = []string{
+	"Entity saved",
+	"Entity trashed",
+	"Oops...",
+}
+
+// normalizeLocale returns the locale in lower case with dashes,
+// e.g. "de-at" for "de_AT"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// replacePlaceholders replaces the {name} placeholders of the message
+// with the params
+func replacePlaceholders(message string, params map[string]string) string {
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+
+	return message
+}
+
+// requestLocale returns the locale of the request, from FuncLocale,
+// or else the first language of the Accept-Language header
+func requestLocale(funcLocale func(r *http.Request) string, r *http.Request) string {
+	if funcLocale != nil {
+		return funcLocale(r)
+	}
+
+	first, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	locale, _, _ := strings.Cut(first, ";")
+
+	return normalizeLocale(locale)
+}
+
+// t translates the key in the locale of the request handled by the Crud
+//
+// Parameters:
+// - key: the English string, with {name} placeholders
+// - params: the names and values of the placeholders, in pairs
+//
+// Returns:
+// - string - the translated string
+func (crud *Crud) t(key string, params ...string) string {
+	values := map[string]string{}
+
+	for index := 0; index+1 < len(params); index += 2 {
+		values[params[index]] = params[index+1]
+	}
+
+	if crud.translator == nil {
+		return replacePlaceholders(key, values)
+	}
+
+	return crud.translator.Translate(crud.locale, key, values)
+}
+
+// tEntity translates the key with the {name} placeholder replaced by
+// the translated singular name of the entity, e.g. "New {name}"
+func (crud *Crud) tEntity(key string) string {
+	return crud.t(key, "name", crud.t(crud.entityNameSingular))
+}
+
+// scriptTranslations returns the script defining crudT, which
+// translates the messages of the scripts in the locale of the request
+func (crud *Crud) scriptTranslations() string {
+	messages := map[string]string{}
+
+	for _, key := range scriptMessages {
+		messages[key] = crud.t(key)
+	}
+
+	messagesJSON, _ := utils.ToJSON(messages)
+
+	return `
+const crudMessages = ` + messagesJSON + `;
+const crudT = (key) => crudMessages[key] || key;
+`
+}
+
+// translateOptions returns the options with their values translated
+func (crud *Crud) translateOptions(options []form.FieldOption) []form.FieldOption {
+	return lo.Map(options, func(option form.FieldOption, _ int) form.FieldOption {
+		option.Value = crud.t(option.Value)
+		return option
+	})
+}
+
+// translateFields returns copies of the fields with their labels, help
+// and options translated, to be shown in the forms
+func (crud *Crud) translateFields(fields []form.FieldInterface) []form.FieldInterface {
+	return lo.Map(fields, func(field form.FieldInterface, _ int) form.FieldInterface {
+		return crud.translateField(field)
+	})
+}
+
+// translateField returns a copy of the field with its label, help and
+// options translated. The fields of other types are returned as is.
+func (crud *Crud) translateField(field form.FieldInterface) form.FieldInterface {
+	switch typed := field.(type) {
+	case *form.Field:
+		clone := *typed
+		clone.Label = crud.t(lo.Ternary(clone.Label == "", clone.Name, clone.Label))
+		clone.Help = lo.Ternary(clone.Help == "", "", crud.t(clone.Help))
+		clone.Options = crud.translateOptions(fieldOptions(typed))
+		clone.OptionsF = nil
+		return &clone
+	case *ChoiceField:
+		clone := crud.translateField(typed.Field).(*form.Field)
+		if clone.Type == FORM_FIELD_TYPE_TAGS {
+			clone.Placeholder = crud.t(lo.CoalesceOrEmpty(typed.Placeholder, tagsPlaceholder))
+		}
+		return &ChoiceField{Field: clone}
+	case *ConditionalField:
+		clone := *typed
+		clone.FieldInterface = crud.translateField(typed.FieldInterface)
+		return &clone
+	}
+
+	return field
+}
@@ -0,0 +1,92 @@
+package crud
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ServeHTTP implements http.Handler, so that the Crud can be mounted on
+// any router, e.g. mux.Handle("/users/", http.StripPrefix("/users", crud))
+func (crud Crud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	crud.Handler(w, r)
+}
+
+// pathRoute returns the route of the clean path of the request, with
+// the prefix of the endpoint stripped:
+//   - "/" - the entity manager
+//   - "/{id}" - the read page of the entity
+//   - "/{id}/edit" - the update page of the entity
+//
+// The ID of the entity is set as the entity_id parameter of the
+// returned request.
+//
+// Parameters:
+// - r: the HTTP request
+//
+// Returns:
+// - string - the route
+// - *http.Request - the request with the ID of the entity
+// - bool - false if the path is not a route
+func pathRoute(r *http.Request) (string, *http.Request, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(segments) == 1 && segments[0] == "" {
+		return pathEntityManager, r, true
+	}
+
+	if len(segments) > 2 || (len(segments) == 2 && segments[1] != "edit") {
+		return "", r, false
+	}
+
+	routed := r.Clone(r.Context())
+	query := routed.URL.Query()
+	query.Set("entity_id", segments[0])
+	routed.URL.RawQuery = query.Encode()
+
+	// the form may have been parsed before the query was set
+	if routed.Form != nil {
+		routed.Form.Set("entity_id", segments[0])
+	}
+
+	return lo.Ternary(len(segments) == 2, pathEntityUpdate, pathEntityRead), routed, true
+}
+
+// UrlEntityReadByID returns the URL of the read page of the entity,
+// "/users/{id}" with path routing
+func (crud *Crud) UrlEntityReadByID(entityID string) string {
+	if crud.pathRouting {
+		return crud.pathURL(entityID)
+	}
+
+	return crud.UrlEntityRead() + "&entity_id=" + url.QueryEscape(entityID)
+}
+
+// UrlEntityUpdateByID returns the URL of the update page of the entity,
+// "/users/{id}/edit" with path routing
+func (crud *Crud) UrlEntityUpdateByID(entityID string) string {
+	if crud.pathRouting {
+		return crud.pathURL(entityID) + "/edit"
+	}
+
+	return crud.UrlEntityUpdate() + "&entity_id=" + url.QueryEscape(entityID)
+}
+
+// pathURL returns the clean path of the entity, below the endpoint
+func (crud *Crud) pathURL(entityID string) string {
+	endpoint, query, _ := strings.Cut(crud.endpoint, "?")
+
+	return strings.TrimSuffix(endpoint, "/") + "/" + url.PathEscape(entityID) + lo.Ternary(query == "", "", "?"+query)
+}
+
+// appendQuery appends the query parameters to the URL,
+// e.g. appendQuery("/users?path=entity-manager", "view=open")
+func appendQuery(rawURL string, query string) string {
+	if query == "" {
+		return rawURL
+	}
+
+	return rawURL + lo.Ternary(strings.Contains(rawURL, "?"), "&", "?") + query
+}
@@ -0,0 +1,161 @@
+package crud
+
+// scriptFormHelpers contains the global JavaScript helpers
+// used by the Vue forms
+const scriptFormHelpers = `
+function crudSerializeModel(model) {
+	const data = {};
+	Object.keys(model).forEach((key) => {
+		const value = model[key];
+		const isObject = value !== null && typeof value === "object";
+		data[key] = isObject ? JSON.stringify(value) : value;
+	});
+	return data;
+}
+function crudConditionsMatch(model, conditions) {
+	return (conditions || []).every((condition) => {
+		const value = model[condition.Field];
+		const selected = Array.isArray(value) ? value.map(String) : [value === null || value === undefined ? "" : String(value)];
+		const isEmpty = selected.filter((item) => item !== "").length === 0;
+		const values = condition.Values || [];
+		switch (condition.Operator) {
+			case "neq": return !selected.includes(condition.Value);
+			case "in": return selected.some((item) => values.includes(item));
+			case "not_in": return !selected.some((item) => values.includes(item));
+			case "empty": return isEmpty;
+			case "not_empty": return !isEmpty;
+		}
+		return selected.includes(condition.Value);
+	});
+}
+`
+
+// scriptFormMethods contains the Vue methods used by the form fields,
+// shared by the create and the update forms
+const scriptFormMethods = `
+		tagAdd(fieldName, event){
+			const tag = event.target.value.trim();
+			event.target.value = "";
+			if (tag === "") return;
+			if (!Array.isArray(this.entityModel[fieldName])) this.entityModel[fieldName] = [];
+			if (this.entityModel[fieldName].includes(tag)) return;
+			this.entityModel[fieldName].push(tag);
+		},
+		tagRemove(fieldName, index){
+			this.entityModel[fieldName].splice(index, 1);
+		},
+		conditionsMatch(conditions){
+			return crudConditionsMatch(this.entityModel, conditions);
+		},
+		optionsWatch(configs){
+			(configs || []).forEach((config) => {
+				this.$watch(() => JSON.stringify(config.dependsOn.map((name) => this.entityModel[name])), () => this.optionsLoad(config));
+			});
+		},
+		optionsLoad(config){
+			$.post(config.url, crudSerializeModel(this.entityModel)).done((response)=>{
+				if (response.status !== "success") return;
+				const options = response.data.options || [];
+				this.tmp["options_" + config.field] = options;
+				const value = this.entityModel[config.field];
+				const keys = options.map((option) => option.key);
+				if (Array.isArray(value)) {
+					this.entityModel[config.field] = value.filter((item) => keys.includes(item));
+				} else if (value !== "" && value !== null && value !== undefined && !keys.includes(value)) {
+					this.entityModel[config.field] = "";
+				}
+			});
+		},
+`
+
+// scriptConditions evaluates the conditions of the ConditionalFields
+// of the htmx forms, showing and hiding the fields and reloading the
+// dependent options when the values of the form change
+const scriptConditions = `
+function crudFormValues(container) {
+	const values = {};
+	container.querySelectorAll("[name]").forEach((element) => {
+		const name = element.name.replace(/\[\]$/, "");
+		const isCheckbox = element.type === "checkbox" || element.type === "radio";
+		if (element.type === "hidden" && container.querySelector('input[type=checkbox][name="' + element.name + '"]')) return;
+		if (isCheckbox && !element.checked) {
+			if (element.type === "checkbox" && container.querySelector('input[type=hidden][name="' + element.name + '"]')) values[name] = "0";
+			return;
+		}
+		const selected = element.multiple ? Array.from(element.selectedOptions).map((option) => option.value) : [element.value];
+		values[name] = name in values ? [].concat(values[name], selected) : (element.multiple ? selected : selected[0]);
+	});
+	return values;
+}
+function crudFormFill(container, values) {
+	if (!container) return;
+	container.querySelectorAll("[name]").forEach((element) => {
+		const name = element.name.replace(/\[\]$/, "");
+		if (!(name in values) || element.type === "hidden") return;
+		const value = values[name] === null || values[name] === undefined ? "" : String(values[name]);
+		let selected = [value];
+		if (value.startsWith("[")) {
+			try { selected = JSON.parse(value).map(String); } catch (error) {}
+		}
+		const isBoolean = element.type === "checkbox" && container.querySelector('input[type=hidden][name="' + element.name + '"]');
+		if (isBoolean) {
+			element.checked = ["1", "true", "on", "yes"].includes(value.toLowerCase());
+		} else if (element.type === "checkbox" || element.type === "radio") {
+			element.checked = selected.includes(element.value);
+		} else if (element.multiple) {
+			Array.from(element.options).forEach((option) => { option.selected = selected.includes(option.value); });
+		} else {
+			element.value = value;
+		}
+	});
+}
+function crudConditionsInit(container) {
+	if (!container) return;
+	const refresh = () => {
+		const values = crudFormValues(container);
+		container.querySelectorAll("[data-crud-show-if]").forEach((group) => {
+			group.style.display = crudConditionsMatch(values, JSON.parse(group.dataset.crudShowIf)) ? "" : "none";
+		});
+		container.querySelectorAll("[data-crud-required-if]").forEach((group) => {
+			const label = group.querySelector("label");
+			if (!label) return;
+			let marker = label.querySelector(".crud-required-marker");
+			if (!marker) {
+				marker = document.createElement("sup");
+				marker.className = "crud-required-marker text-danger ml-1";
+				marker.textContent = "*";
+				label.appendChild(marker);
+			}
+			marker.style.display = crudConditionsMatch(values, JSON.parse(group.dataset.crudRequiredIf)) ? "" : "none";
+		});
+	};
+	const reload = (group) => {
+		const select = group.querySelector("select");
+		if (!select) return;
+		const data = new URLSearchParams();
+		const values = crudFormValues(container);
+		Object.keys(values).forEach((key) => data.append(key, Array.isArray(values[key]) ? JSON.stringify(values[key]) : values[key]));
+		fetch(group.dataset.crudOptionsUrl, {method: "POST", body: data}).then((response) => response.json()).then((response) => {
+			if (response.status !== "success") return;
+			const selected = Array.from(select.selectedOptions).map((option) => option.value);
+			select.innerHTML = "";
+			(response.data.options || []).forEach((option) => {
+				select.add(new Option(option.value, option.key, false, selected.includes(option.key)));
+			});
+			if (!select.multiple && !selected.includes(select.value)) select.value = "";
+			select.dispatchEvent(new Event("change", {bubbles: true}));
+		});
+	};
+	const dependents = Array.from(container.querySelectorAll("[data-crud-depends-on]"));
+	container.addEventListener("change", (event) => {
+		refresh();
+		const name = (event.target.name || "").replace(/\[\]$/, "");
+		dependents.forEach((group) => {
+			if (JSON.parse(group.dataset.crudDependsOn).includes(name)) reload(group);
+		});
+	});
+	container.addEventListener("input", refresh);
+	refresh();
+	dependents.forEach(reload);
+}
+`
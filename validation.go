@@ -0,0 +1,69 @@
+package crud
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// validateFields validates the posted values of the fields: the
+// required fields are filled in, the fields with dependent options hold
+// the available options and the JSON fields hold valid JSON matching
+// their JSONSchema. The fields hidden by their conditions
+// are skipped.
+//
+// Parameters:
+// - fields: the fields of the form
+// - posts: the posted values, keyed by field name
+//
+// Returns:
+// - string - the error message, or an empty string if all is valid
+func (crud *Crud) validateFields(fields []FormField, posts map[string]string) string {
+	if errorMessage := crud.requiredFieldsError(fields, posts); errorMessage != "" {
+		return errorMessage
+	}
+
+	for _, field := range fields {
+		if errorMessage := crud.dependentOptionsError(field, posts); errorMessage != "" {
+			return errorMessage
+		}
+
+		if field.Type != FORM_FIELD_TYPE_JSON || !field.isVisible(posts) {
+			continue
+		}
+
+		if strings.TrimSpace(posts[field.Name]) == "" {
+			continue
+		}
+
+		if errs := validateJSON(posts[field.Name], crud.jsonSchemas[field.JSONSchema]); len(errs) > 0 {
+			label := lo.Ternary(field.Label == "", field.Name, field.Label)
+			return crud.t("{label} is not valid: {error}", "label", crud.t(label), "error", strings.Join(errs, "; "))
+		}
+	}
+
+	return ""
+}
+
+// dependentOptionsError returns the error message if the posted value
+// of a field with dependent options is not one of the options for the
+// posted values, e.g. a region of another country
+func (crud *Crud) dependentOptionsError(field FormField, posts map[string]string) string {
+	if !field.hasDependentOptions() || !field.isVisible(posts) {
+		return ""
+	}
+
+	selected := lo.Ternary(field.isMultiValued(), DecodeMultiValue(posts[field.Name]), []string{posts[field.Name]})
+
+	keys := lo.Map(append(field.options(), field.DependentOptionsF(posts)...), func(option FormFieldOption, _ int) string {
+		return option.Key
+	})
+
+	if len(lo.Without(lo.Compact(selected), keys...)) == 0 {
+		return ""
+	}
+
+	label := lo.Ternary(field.Label == "", field.Name, field.Label)
+
+	return crud.t("{label} has an option which is not available", "label", crud.t(label))
+}
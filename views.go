@@ -0,0 +1,243 @@
+package crud
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gouniverse/api"
+	"github.com/gouniverse/hb"
+	"github.com/gouniverse/icons"
+	"github.com/gouniverse/utils"
+	"github.com/samber/lo"
+)
+
+// preferenceKey returns the key of the preference of the entity,
+// e.g. "crud.products.views"
+func (crud *Crud) preferenceKey(name string) string {
+	entity := Slugify(crud.entityNamePlural)
+	return "crud." + lo.Ternary(entity == "", "entities", entity) + "." + name
+}
+
+// userID returns the ID of the user the preferences are stored for,
+// FuncUserID being required by the PreferencesStore
+func (crud *Crud) userID(r *http.Request) string {
+	return crud.funcUserID(r)
+}
+
+// isViewsEnabled returns true if the views can be saved
+func (crud *Crud) isViewsEnabled() bool {
+	return crud.preferencesStore != nil
+}
+
+// views returns the saved views of the user, none if the
+// views are not enabled or cannot be read
+func (crud *Crud) views(r *http.Request) []View {
+	views := []View{}
+
+	if !crud.isViewsEnabled() {
+		return views
+	}
+
+	value, err := crud.preferencesStore.Get(crud.userID(r), crud.preferenceKey("views"))
+
+	if err != nil || value == "" {
+		return views
+	}
+
+	if err := json.Unmarshal([]byte(value), &views); err != nil {
+		return []View{}
+	}
+
+	return views
+}
+
+// saveViews saves the views of the user
+func (crud *Crud) saveViews(r *http.Request, views []View) error {
+	value, err := json.Marshal(views)
+
+	if err != nil {
+		return err
+	}
+
+	return crud.preferencesStore.Set(crud.userID(r), crud.preferenceKey("views"), string(value))
+}
+
+// hasRowsQueryParams returns true if the URL query parameters hold
+// filters, a sort or visible columns
+func hasRowsQueryParams(params url.Values) bool {
+	return lo.SomeBy(lo.Keys(params), func(name string) bool {
+		return strings.HasPrefix(name, "filter_") || name == "sort" || name == "columns"
+	})
+}
+
+// rowsQuery returns the query of the rows of the request. The query
+// held by the URL query parameters takes precedence. Otherwise the
+// query of the view set by the view parameter is used, or of the
+// default view if the parameter is absent.
+//
+// Parameters:
+// - r: the HTTP request
+//
+// Returns:
+// - RowsQuery - the query of the rows
+// - string - the name of the current view, empty if none
+func (crud *Crud) rowsQuery(r *http.Request) (RowsQuery, string) {
+	params := r.URL.Query()
+	views := crud.views(r)
+	viewName := params.Get("view")
+
+	if !params.Has("view") {
+		view, _ := lo.Find(views, func(view View) bool {
+			return view.IsDefault
+		})
+		viewName = view.Name
+	}
+
+	view, found := lo.Find(views, func(view View) bool {
+		return viewName != "" && view.Name == viewName
+	})
+
+	if !found {
+		return crud.parseRowsQuery(params), ""
+	}
+
+	if hasRowsQueryParams(params) {
+		return crud.parseRowsQuery(params), view.Name
+	}
+
+	// the query is parsed again, dropping the columns and the
+	// filters removed since the view was saved
+	return crud.parseRowsQuery(rowsQueryParams(view.Query)), view.Name
+}
+
+// visibleColumns returns the indexes of the visible columns of the
+// query, in the order shown, all the columns if none
+func (crud *Crud) visibleColumns(query RowsQuery) []int {
+	indexes := lo.Range(len(crud.columns))
+
+	if len(query.Columns) == 0 {
+		return indexes
+	}
+
+	return lo.FilterMap(query.Columns, func(name string, _ int) (int, bool) {
+		return lo.Find(indexes, func(index int) bool {
+			return crud.columns[index].label() == name
+		})
+	})
+}
+
+// viewSwitcher generates the dropdown of the entity manager heading,
+// switching between the saved views of the user
+func (crud *Crud) viewSwitcher(r *http.Request, viewName string) hb.TagInterface {
+	if !crud.isViewsEnabled() {
+		return nil
+	}
+
+	item := func(name string, label string) hb.TagInterface {
+		return hb.LI().Child(hb.Hyperlink().
+			Class("dropdown-item" + lo.Ternary(name == viewName, " active", "")).
+			Href(appendQuery(crud.UrlEntityManager(), "view="+url.QueryEscape(name))).
+			Text(label))
+	}
+
+	items := lo.Map(crud.views(r), func(view View, _ int) hb.TagInterface {
+		return item(view.Name, lo.Ternary(view.IsDefault, crud.t("{name} (default)", "name", view.Name), view.Name))
+	})
+
+	return hb.Div().
+		Class("dropdown float-end me-2").
+		Child(hb.Button().
+			Type(hb.TYPE_BUTTON).
+			Class(crud.theme.ButtonClass(BUTTON_OUTLINE_SECONDARY, false)+" dropdown-toggle").
+			Attr("data-bs-toggle", "dropdown").
+			Child(icons.Icon("bi-eye", 16, 16, "#333").Style("margin-top:-4px;margin-right:8px;")).
+			Text(lo.Ternary(viewName == "", crud.t("All"), viewName))).
+		Child(hb.UL().
+			Class("dropdown-menu dropdown-menu-end").
+			Child(item("", crud.t("All"))).
+			Children(items).
+			Child(hb.LI().Child(hb.HR().Class("dropdown-divider"))).
+			Child(hb.LI().Child(hb.Button().
+				Type(hb.TYPE_BUTTON).
+				Class("dropdown-item").
+				Attr("v-on:click", "viewSave").
+				Text(crud.t("Save view...")))).
+			ChildIf(viewName != "", hb.LI().Child(hb.Button().
+				Type(hb.TYPE_BUTTON).
+				Class("dropdown-item text-danger").
+				Attr("v-on:click", "viewDelete").
+				Text(crud.t("Delete view")))))
+}
+
+// pageEntityViewSaveAjax saves the posted query of the entity manager
+// as a view of the user, replacing the view with the same name. A
+// default view replaces the previous default.
+func (crud *Crud) pageEntityViewSaveAjax(w http.ResponseWriter, r *http.Request) {
+	if !crud.isViewsEnabled() {
+		api.Respond(w, r, api.Error(crud.t("Views are not enabled")))
+		return
+	}
+
+	name := strings.TrimSpace(utils.Req(r, "name", ""))
+
+	if name == "" {
+		api.Respond(w, r, api.Error(crud.t("Name is required")))
+		return
+	}
+
+	params, err := url.ParseQuery(strings.TrimPrefix(utils.Req(r, "query", ""), "?"))
+
+	if err != nil {
+		api.Respond(w, r, api.Error(crud.t("Query is invalid")))
+		return
+	}
+
+	view := View{
+		Name:      name,
+		IsDefault: utils.Req(r, "default", "") == "1",
+		Query:     crud.parseRowsQuery(params),
+	}
+
+	views := lo.Map(crud.views(r), func(existing View, _ int) View {
+		existing.IsDefault = existing.IsDefault && !view.IsDefault
+		return existing
+	})
+
+	if _, index, found := lo.FindIndexOf(views, func(existing View) bool { return existing.Name == name }); found {
+		views[index] = view
+	} else {
+		views = append(views, view)
+	}
+
+	if err := crud.saveViews(r, views); err != nil {
+		api.Respond(w, r, api.Error(crud.t("Save failed: {error}", "error", err.Error())))
+		return
+	}
+
+	api.Respond(w, r, api.SuccessWithData(crud.t("View saved"), map[string]interface{}{
+		"name": name,
+	}))
+}
+
+// pageEntityViewDeleteAjax deletes the view of the user
+func (crud *Crud) pageEntityViewDeleteAjax(w http.ResponseWriter, r *http.Request) {
+	if !crud.isViewsEnabled() {
+		api.Respond(w, r, api.Error(crud.t("Views are not enabled")))
+		return
+	}
+
+	name := strings.TrimSpace(utils.Req(r, "name", ""))
+
+	views := lo.Reject(crud.views(r), func(view View, _ int) bool {
+		return view.Name == name
+	})
+
+	if err := crud.saveViews(r, views); err != nil {
+		api.Respond(w, r, api.Error(crud.t("Delete failed: {error}", "error", err.Error())))
+		return
+	}
+
+	api.Respond(w, r, api.Success(crud.t("View deleted")))
+}